@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/configs"
+	"github.com/drprado2/go-backend-framework/pkg/dependencyinjection"
+	"github.com/drprado2/go-backend-framework/pkg/httpresults"
+	"github.com/drprado2/go-backend-framework/pkg/storage"
+	"github.com/drprado2/go-backend-framework/pkg/storage/postgres"
+	"log"
+	"net"
+	"net/http"
+	"time"
+)
+
+const shutdownTimeout = 15 * time.Second
+
+type database struct {
+	storage.FullDatabaseInterface
+}
+
+func newDatabase(factory *postgres.DatabaseFactory) (*database, error) {
+	db, err := factory.GetDB()
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to the database\nError: %w", err)
+	}
+	return &database{db}, nil
+}
+
+// Start and Stop make the database a hosted service so it is closed after the services using it
+func (db *database) Start(ctx context.Context) error {
+	return db.PingContext(ctx)
+}
+
+func (db *database) Stop(ctx context.Context) error {
+	return db.Close()
+}
+
+type httpServer struct {
+	server *http.Server
+	db     *database
+}
+
+func newHttpServer(options *configs.HttpServerOptions, db *database) *httpServer {
+	server := &httpServer{db: db}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", server.health)
+	server.server = &http.Server{
+		Addr:    ":" + options.Port,
+		Handler: mux,
+	}
+	return server
+}
+
+func (s *httpServer) health(w http.ResponseWriter, r *http.Request) {
+	result := commands.NewCommandResult()
+	if err := s.db.PingContext(r.Context()); err != nil {
+		result = result.Fail(commands.InternalErrorCodeResult, err)
+	}
+	httpresults.WriteResult(w, r, result, nil)
+}
+
+func (s *httpServer) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Http server stopped\nError: %s", err)
+		}
+	}()
+	log.Printf("Http server listening on %s", s.server.Addr)
+	return nil
+}
+
+func (s *httpServer) Stop(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+func registerServices(builder *dependencyinjection.ServiceBuilder) error {
+	if err := builder.AddSingletonConstructor(configs.GetConfig, nil); err != nil {
+		return err
+	}
+	if err := builder.AddOptions(configs.DatabaseSection, &configs.DatabaseOptions{}); err != nil {
+		return err
+	}
+	if err := builder.AddOptions(configs.HttpServerSection, &configs.HttpServerOptions{Port: "9000"}); err != nil {
+		return err
+	}
+	if err := builder.AddSingletonConstructor(postgres.NewDatabaseFactoryFromOptions, nil); err != nil {
+		return err
+	}
+	if err := builder.AddHostedService(newDatabase, nil); err != nil {
+		return err
+	}
+	return builder.AddHostedService(newHttpServer, nil)
+}
+
+func main() {
+	builder := dependencyinjection.NewServiceBuilder()
+	if err := registerServices(builder); err != nil {
+		log.Fatalf("Error registering the services\nError: %s", err)
+	}
+	provider, err := builder.BuildServiceProvider()
+	if err != nil {
+		log.Fatalf("Error building the services\nError: %s", err)
+	}
+	if err := dependencyinjection.NewHost(provider, shutdownTimeout).Run(context.Background()); err != nil {
+		log.Fatalf("Error running the transaction manager\nError: %s", err)
+	}
+}
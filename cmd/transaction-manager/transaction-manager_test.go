@@ -0,0 +1,15 @@
+package main
+
+import (
+	"github.com/drprado2/go-backend-framework/pkg/dependencyinjection"
+	"github.com/drprado2/go-backend-framework/pkg/tests/testutilities"
+	"testing"
+)
+
+func TestRegisterServices(t *testing.T) {
+	builder := dependencyinjection.NewServiceBuilder()
+	if err := registerServices(builder); err != nil {
+		t.Fatalf("Error registering services\nError: %s", err)
+	}
+	testutilities.AssertServiceRegistrations(t, builder)
+}
@@ -1,11 +1,14 @@
 module github.com/drprado2/go-backend-framework
 
-go 1.15
+go 1.21
 
 require (
-	github.com/ghodss/yaml v1.0.0 // indirect
 	github.com/google/uuid v1.1.2
 	github.com/lib/pq v1.8.0
 	github.com/tkanos/gonfig v0.0.0-20181112185242-896f3d81fadf
+)
+
+require (
+	github.com/ghodss/yaml v1.0.0 // indirect
 	gopkg.in/yaml.v2 v2.3.0 // indirect
 )
@@ -0,0 +1,16 @@
+package afterexecute
+
+import (
+	"context"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+)
+
+type Interface interface {
+	Execute(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult
+}
+
+type DefaultAfterExecuteCommand struct{}
+
+func (*DefaultAfterExecuteCommand) Execute(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult {
+	return result
+}
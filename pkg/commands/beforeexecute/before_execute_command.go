@@ -1,13 +1,16 @@
 package beforeexecute
 
-import "github.com/drprado2/go-backend-framework/pkg/commands"
+import (
+	"context"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+)
 
 type Interface interface {
-	Execute(command commands.CommandBase, result commands.CommandResult) commands.CommandResult
+	Execute(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult
 }
 
 type DefaultBeforeExecuteCommand struct {}
 
-func (*DefaultBeforeExecuteCommand) Execute(command commands.CommandBase, result commands.CommandResult) commands.CommandResult{
+func (*DefaultBeforeExecuteCommand) Execute(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult{
 	return result
 }
\ No newline at end of file
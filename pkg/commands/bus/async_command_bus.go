@@ -0,0 +1,217 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"reflect"
+	"sync"
+)
+
+var ErrAsyncCommandBusClosed = errors.New("The async command bus is closed")
+
+type AsyncCommandBusInterface interface {
+	CommandBusInterface
+	DispatchAsync(ctx context.Context, command commands.Command) (*CommandFuture, error)
+	Shutdown(ctx context.Context) error
+}
+
+type CommandFuture struct {
+	done   chan struct{}
+	result commands.CommandResult
+	err    error
+}
+
+func newCommandFuture() *CommandFuture {
+	return &CommandFuture{
+		done: make(chan struct{}),
+	}
+}
+
+func (future *CommandFuture) complete(result commands.CommandResult, err error) {
+	future.result = result
+	future.err = err
+	close(future.done)
+}
+
+// Done is closed when the command result is available
+func (future *CommandFuture) Done() <-chan struct{} {
+	return future.done
+}
+
+func (future *CommandFuture) Wait(ctx context.Context) (commands.CommandResult, error) {
+	select {
+	case <-future.done:
+		return future.result, future.err
+	case <-ctx.Done():
+		return commands.CommandResult{}, ctx.Err()
+	}
+}
+
+type asyncJob struct {
+	ctx     context.Context
+	command commands.Command
+	future  *CommandFuture
+}
+
+type AsyncCommandBus struct {
+	CommandBusInterface
+	capacity          int
+	jobs              chan asyncJob
+	ready             chan asyncJob
+	finished          chan reflect.Type
+	stopping          chan struct{}
+	concurrencyLimits map[reflect.Type]int
+	limitsMutex       sync.RWMutex
+	dispatchMutex     sync.RWMutex
+	workers           sync.WaitGroup
+	shutdownOnce      sync.Once
+	closed            bool
+}
+
+// NewAsyncCommandBus starts the workers and the scheduler that gives them the commands, at most
+// workers plus queueSize commands are running or queued and DispatchAsync blocks while it is full
+func NewAsyncCommandBus(bus CommandBusInterface, workers int, queueSize int) (*AsyncCommandBus, error) {
+	if workers < 1 || queueSize < 0 {
+		return nil, fmt.Errorf("The workers must be greater than 0 and the queue size must not be negative")
+	}
+	asyncBus := &AsyncCommandBus{
+		CommandBusInterface: bus,
+		capacity:            workers + queueSize,
+		jobs:                make(chan asyncJob),
+		ready:               make(chan asyncJob),
+		finished:            make(chan reflect.Type),
+		stopping:            make(chan struct{}),
+		concurrencyLimits:   make(map[reflect.Type]int),
+	}
+	for i := 0; i < workers; i++ {
+		asyncBus.workers.Add(1)
+		go asyncBus.work()
+	}
+	go asyncBus.schedule()
+	return asyncBus, nil
+}
+
+// SetConcurrencyLimit limits how many commands of the type run at the same time, the commands over the
+// limit stay queued without holding a worker so the other types keep running
+func (bus *AsyncCommandBus) SetConcurrencyLimit(commandType reflect.Type, limit int) error {
+	if limit < 1 {
+		return fmt.Errorf("The concurrency limit must be greater than 0")
+	}
+	bus.limitsMutex.Lock()
+	defer bus.limitsMutex.Unlock()
+	bus.concurrencyLimits[commandKey(commandType)] = limit
+	return nil
+}
+
+func (bus *AsyncCommandBus) DispatchAsync(ctx context.Context, command commands.Command) (*CommandFuture, error) {
+	if command == nil {
+		return nil, fmt.Errorf("The command must not be null")
+	}
+
+	bus.dispatchMutex.RLock()
+	defer bus.dispatchMutex.RUnlock()
+	if bus.closed {
+		return nil, ErrAsyncCommandBusClosed
+	}
+
+	future := newCommandFuture()
+	select {
+	case bus.jobs <- asyncJob{ctx: ctx, command: command, future: future}:
+		return future, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-bus.stopping:
+		return nil, ErrAsyncCommandBusClosed
+	}
+}
+
+// Shutdown stops accepting commands and waits the queued ones to finish until the ctx is done
+func (bus *AsyncCommandBus) Shutdown(ctx context.Context) error {
+	bus.shutdownOnce.Do(func() {
+		close(bus.stopping)
+		bus.dispatchMutex.Lock()
+		bus.closed = true
+		close(bus.jobs)
+		bus.dispatchMutex.Unlock()
+	})
+
+	drained := make(chan struct{})
+	go func() {
+		bus.workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (bus *AsyncCommandBus) hasFreeSlot(key reflect.Type, running map[reflect.Type]int) bool {
+	bus.limitsMutex.RLock()
+	defer bus.limitsMutex.RUnlock()
+	limit, limited := bus.concurrencyLimits[key]
+	return !limited || running[key] < limit
+}
+
+// schedule keeps the queued commands in arrival order and gives a worker the first one whose type has
+// a free slot, it closes ready after the jobs are closed and every queued command finished
+func (bus *AsyncCommandBus) schedule() {
+	defer close(bus.ready)
+	incoming := bus.jobs
+	queued := make([]asyncJob, 0, bus.capacity)
+	running := make(map[reflect.Type]int)
+	active := 0
+
+	for incoming != nil || len(queued) > 0 || active > 0 {
+		var ready chan asyncJob
+		var next asyncJob
+		position := -1
+		for i, job := range queued {
+			if bus.hasFreeSlot(commandKey(reflect.TypeOf(job.command)), running) {
+				ready, next, position = bus.ready, job, i
+				break
+			}
+		}
+		accept := incoming
+		if len(queued)+active >= bus.capacity {
+			accept = nil
+		}
+
+		select {
+		case job, ok := <-accept:
+			if !ok {
+				incoming = nil
+				continue
+			}
+			queued = append(queued, job)
+		case ready <- next:
+			queued = append(queued[:position], queued[position+1:]...)
+			running[commandKey(reflect.TypeOf(next.command))]++
+			active++
+		case key := <-bus.finished:
+			running[key]--
+			active--
+		}
+	}
+}
+
+func (bus *AsyncCommandBus) work() {
+	defer bus.workers.Done()
+	for job := range bus.ready {
+		bus.run(job)
+		bus.finished <- commandKey(reflect.TypeOf(job.command))
+	}
+}
+
+func (bus *AsyncCommandBus) run(job asyncJob) {
+	if err := job.ctx.Err(); err != nil {
+		job.future.complete(commands.CommandResult{}, err)
+		return
+	}
+	job.future.complete(bus.Dispatch(job.ctx, job.command))
+}
@@ -0,0 +1,205 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/commands/handlers"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type slowCommand struct {
+	commands.CommandBase
+	Value int
+}
+
+type otherCommand struct {
+	commands.CommandBase
+}
+
+// slowHandler signals started when a command begins and holds it until release receives or is closed
+type slowHandler struct {
+	started    chan int
+	release    chan struct{}
+	running    int32
+	maxRunning int32
+	handled    int32
+}
+
+func newSlowHandler() *slowHandler {
+	return &slowHandler{
+		started: make(chan int, 100),
+		release: make(chan struct{}),
+	}
+}
+
+func (handler *slowHandler) Handle(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult {
+	running := atomic.AddInt32(&handler.running, 1)
+	for {
+		max := atomic.LoadInt32(&handler.maxRunning)
+		if running <= max || atomic.CompareAndSwapInt32(&handler.maxRunning, max, running) {
+			break
+		}
+	}
+	handler.started <- command.(*slowCommand).Value
+	<-handler.release
+	atomic.AddInt32(&handler.running, -1)
+	atomic.AddInt32(&handler.handled, 1)
+	result.ResultData = command.(*slowCommand).Value * 2
+	return result
+}
+
+type otherHandler struct{}
+
+func (handler *otherHandler) Handle(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult {
+	return result
+}
+
+func newAsyncTestBus(t *testing.T, handler *slowHandler, workers int, queueSize int) *AsyncCommandBus {
+	commandBus := NewCommandBus(handlers.NewCommandHandlerExecutor())
+	commandBus.Register(reflect.TypeOf(slowCommand{}), handler)
+	commandBus.Register(reflect.TypeOf(otherCommand{}), &otherHandler{})
+	asyncBus, err := NewAsyncCommandBus(commandBus, workers, queueSize)
+	if err != nil {
+		t.Fatalf("Error creating async bus\nError: %s", err)
+	}
+	return asyncBus
+}
+
+func TestAsyncCommandBus_DispatchAsync(t *testing.T) {
+	handler := newSlowHandler()
+	close(handler.release)
+	asyncBus := newAsyncTestBus(t, handler, 2, 10)
+	defer asyncBus.Shutdown(context.Background())
+
+	future, err := asyncBus.DispatchAsync(context.Background(), &slowCommand{Value: 21})
+	if err != nil {
+		t.Fatalf("Error dispatching command\nError: %s", err)
+	}
+	result, err := future.Wait(context.Background())
+	if err != nil || result.ResultData != 42 {
+		t.Errorf("Result data must be 42 got %v, error %v", result.ResultData, err)
+	}
+}
+
+func TestAsyncCommandBus_Backpressure(t *testing.T) {
+	handler := newSlowHandler()
+	asyncBus := newAsyncTestBus(t, handler, 1, 1)
+	defer asyncBus.Shutdown(context.Background())
+
+	asyncBus.DispatchAsync(context.Background(), &slowCommand{})
+	<-handler.started
+	if _, err := asyncBus.DispatchAsync(context.Background(), &slowCommand{}); err != nil {
+		t.Fatalf("Dispatch with a free queue position must not fail got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := asyncBus.DispatchAsync(ctx, &slowCommand{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Dispatch on a full queue must wait until the ctx is done got %v", err)
+	}
+
+	close(handler.release)
+	if _, err := asyncBus.DispatchAsync(context.Background(), &slowCommand{}); err != nil {
+		t.Errorf("Dispatch after the queue drained must not fail got %v", err)
+	}
+}
+
+func TestAsyncCommandBus_ConcurrencyLimit(t *testing.T) {
+	handler := newSlowHandler()
+	asyncBus := newAsyncTestBus(t, handler, 4, 10)
+	asyncBus.SetConcurrencyLimit(reflect.TypeOf(&slowCommand{}), 1)
+
+	for i := 0; i < 4; i++ {
+		asyncBus.DispatchAsync(context.Background(), &slowCommand{})
+	}
+	for i := 0; i < 4; i++ {
+		<-handler.started
+		if running := atomic.LoadInt32(&handler.running); running != 1 {
+			t.Errorf("Commands running must be 1 got %v", running)
+		}
+		handler.release <- struct{}{}
+	}
+	asyncBus.Shutdown(context.Background())
+
+	if handler.maxRunning != 1 {
+		t.Errorf("Max commands running at the same time must be 1 got %v", handler.maxRunning)
+	}
+}
+
+func TestAsyncCommandBus_ConcurrencyLimitDoesNotStarveOtherTypes(t *testing.T) {
+	handler := newSlowHandler()
+	asyncBus := newAsyncTestBus(t, handler, 2, 10)
+	asyncBus.SetConcurrencyLimit(reflect.TypeOf(&slowCommand{}), 1)
+
+	for i := 0; i < 3; i++ {
+		asyncBus.DispatchAsync(context.Background(), &slowCommand{})
+	}
+	<-handler.started
+	future, err := asyncBus.DispatchAsync(context.Background(), &otherCommand{})
+	if err != nil {
+		t.Fatalf("Error dispatching command\nError: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := future.Wait(ctx); err != nil {
+		t.Errorf("Command of another type must run while the limited type waits got %v", err)
+	}
+	if handled := atomic.LoadInt32(&handler.handled); handled != 0 {
+		t.Errorf("Limited commands handled must be 0 got %v", handled)
+	}
+
+	close(handler.release)
+	asyncBus.Shutdown(context.Background())
+	if handler.handled != 3 {
+		t.Errorf("Handled commands must be 3 got %v", handler.handled)
+	}
+}
+
+func TestAsyncCommandBus_ShutdownDrainsQueue(t *testing.T) {
+	handler := newSlowHandler()
+	close(handler.release)
+	asyncBus := newAsyncTestBus(t, handler, 1, 5)
+
+	futures := make([]*CommandFuture, 0, 5)
+	for i := 0; i < 5; i++ {
+		future, _ := asyncBus.DispatchAsync(context.Background(), &slowCommand{Value: i})
+		futures = append(futures, future)
+	}
+	if err := asyncBus.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Error on shutdown\nError: %s", err)
+	}
+
+	if handler.handled != 5 {
+		t.Errorf("Handled commands must be 5 got %v", handler.handled)
+	}
+	for i, future := range futures {
+		if result, _ := future.Wait(context.Background()); result.ResultData != i*2 {
+			t.Errorf("Result data must be %v got %v", i*2, result.ResultData)
+		}
+	}
+	if _, err := asyncBus.DispatchAsync(context.Background(), &slowCommand{}); err != ErrAsyncCommandBusClosed {
+		t.Errorf("Dispatch after shutdown must fail got %v", err)
+	}
+}
+
+func TestAsyncCommandBus_CanceledCommand(t *testing.T) {
+	handler := newSlowHandler()
+	asyncBus := newAsyncTestBus(t, handler, 1, 5)
+	defer asyncBus.Shutdown(context.Background())
+
+	asyncBus.DispatchAsync(context.Background(), &slowCommand{})
+	<-handler.started
+	ctx, cancel := context.WithCancel(context.Background())
+	future, _ := asyncBus.DispatchAsync(ctx, &slowCommand{})
+	cancel()
+	close(handler.release)
+
+	if _, err := future.Wait(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("Canceled command must fail with context canceled got %v", err)
+	}
+}
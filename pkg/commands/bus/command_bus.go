@@ -0,0 +1,113 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/commands/handlers"
+	"reflect"
+	"sync"
+)
+
+type CommandBusInterface interface {
+	Register(commandType reflect.Type, handler handlers.CommandHandlerInterface) error
+	Dispatch(ctx context.Context, command commands.Command) (commands.CommandResult, error)
+	GetCommandType(typeName string) (reflect.Type, error)
+}
+
+type HandlerAlreadyRegisteredError struct {
+	CommandType reflect.Type
+}
+
+func (err *HandlerAlreadyRegisteredError) Error() string {
+	return fmt.Sprintf("Already exists one handler registered to the command %v", err.CommandType)
+}
+
+type HandlerNotFoundError struct {
+	CommandType reflect.Type
+}
+
+func (err *HandlerNotFoundError) Error() string {
+	return fmt.Sprintf("There is no handler registered to the command %v", err.CommandType)
+}
+
+type CommandBus struct {
+	executor  handlers.CommandHandlerExecutorInterface
+	handlers  map[reflect.Type]handlers.CommandHandlerInterface
+	typeNames map[string]reflect.Type
+	mutex     sync.RWMutex
+}
+
+func NewCommandBus(executor handlers.CommandHandlerExecutorInterface) *CommandBus {
+	return &CommandBus{
+		executor:  executor,
+		handlers:  make(map[reflect.Type]handlers.CommandHandlerInterface),
+		typeNames: make(map[string]reflect.Type),
+	}
+}
+
+// commandKey makes MyCommand and *MyCommand resolve to the same handler
+func commandKey(commandType reflect.Type) reflect.Type {
+	for commandType.Kind() == reflect.Ptr {
+		commandType = commandType.Elem()
+	}
+	return commandType
+}
+
+// CommandTypeName is the name used to persist the command type and find it back with GetCommandType
+func CommandTypeName(commandType reflect.Type) string {
+	key := commandKey(commandType)
+	return key.PkgPath() + "." + key.Name()
+}
+
+func (bus *CommandBus) Register(commandType reflect.Type, handler handlers.CommandHandlerInterface) error {
+	if commandType == nil || handler == nil {
+		return fmt.Errorf("The command type and the handler must not be null")
+	}
+	key := commandKey(commandType)
+
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	if _, exists := bus.handlers[key]; exists {
+		return &HandlerAlreadyRegisteredError{CommandType: key}
+	}
+	bus.handlers[key] = handler
+	bus.typeNames[CommandTypeName(key)] = key
+	return nil
+}
+
+func (bus *CommandBus) GetCommandType(typeName string) (reflect.Type, error) {
+	bus.mutex.RLock()
+	defer bus.mutex.RUnlock()
+
+	commandType, exists := bus.typeNames[typeName]
+	if !exists {
+		return nil, fmt.Errorf("There is no handler registered to the command type name %s", typeName)
+	}
+	return commandType, nil
+}
+
+func (bus *CommandBus) GetHandler(commandType reflect.Type) (handlers.CommandHandlerInterface, error) {
+	key := commandKey(commandType)
+
+	bus.mutex.RLock()
+	defer bus.mutex.RUnlock()
+
+	handler, exists := bus.handlers[key]
+	if !exists {
+		return nil, &HandlerNotFoundError{CommandType: key}
+	}
+	return handler, nil
+}
+
+func (bus *CommandBus) Dispatch(ctx context.Context, command commands.Command) (commands.CommandResult, error) {
+	if command == nil {
+		return commands.CommandResult{}, fmt.Errorf("The command must not be null")
+	}
+	handler, err := bus.GetHandler(reflect.TypeOf(command))
+	if err != nil {
+		return commands.CommandResult{}, err
+	}
+	return bus.executor.ExecuteCommand(ctx, command, handler), nil
+}
@@ -0,0 +1,110 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/commands/handlers"
+	"github.com/google/uuid"
+	"reflect"
+	"testing"
+)
+
+type createUserCommand struct {
+	commands.CommandBase
+	Name string
+}
+
+type deleteUserCommand struct {
+	commands.CommandBase
+}
+
+type createUserHandler struct {
+	handledNames []string
+}
+
+func (handler *createUserHandler) Handle(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult {
+	createUser := command.(*createUserCommand)
+	handler.handledNames = append(handler.handledNames, createUser.Name)
+	result.ResultData = createUser.Name
+	return result
+}
+
+func TestCommandBus_Dispatch(t *testing.T) {
+	bus := NewCommandBus(handlers.NewCommandHandlerExecutor())
+	handler := &createUserHandler{}
+	if err := bus.Register(reflect.TypeOf(createUserCommand{}), handler); err != nil {
+		t.Fatalf("Error registering handler\nError: %s", err)
+	}
+
+	result, err := bus.Dispatch(context.Background(), &createUserCommand{Name: "adriano"})
+	if err != nil {
+		t.Fatalf("Error dispatching command\nError: %s", err)
+	}
+	if !result.IsSuccess() || result.ResultData != "adriano" {
+		t.Errorf("Result data must be adriano got %v", result)
+	}
+	if len(handler.handledNames) != 1 {
+		t.Errorf("Handler must be called once got %v", len(handler.handledNames))
+	}
+}
+
+func TestCommandBus_RegisterDuplicated(t *testing.T) {
+	bus := NewCommandBus(handlers.NewCommandHandlerExecutor())
+	bus.Register(reflect.TypeOf(createUserCommand{}), &createUserHandler{})
+
+	err := bus.Register(reflect.TypeOf(&createUserCommand{}), &createUserHandler{})
+	var duplicatedErr *HandlerAlreadyRegisteredError
+	if !errors.As(err, &duplicatedErr) || duplicatedErr.CommandType != reflect.TypeOf(createUserCommand{}) {
+		t.Errorf("Error must be HandlerAlreadyRegisteredError got %v", err)
+	}
+}
+
+func TestCommandBus_DispatchWithoutHandler(t *testing.T) {
+	bus := NewCommandBus(handlers.NewCommandHandlerExecutor())
+	bus.Register(reflect.TypeOf(createUserCommand{}), &createUserHandler{})
+
+	_, err := bus.Dispatch(context.Background(), &deleteUserCommand{})
+	var notFoundErr *HandlerNotFoundError
+	if !errors.As(err, &notFoundErr) || notFoundErr.CommandType != reflect.TypeOf(deleteUserCommand{}) {
+		t.Errorf("Error must be HandlerNotFoundError got %v", err)
+	}
+}
+
+func TestCommandBus_GetCommandType(t *testing.T) {
+	bus := NewCommandBus(handlers.NewCommandHandlerExecutor())
+	bus.Register(reflect.TypeOf(&createUserCommand{}), &createUserHandler{})
+
+	typeName := CommandTypeName(reflect.TypeOf(createUserCommand{}))
+	if typeName != "github.com/drprado2/go-backend-framework/pkg/commands/bus.createUserCommand" {
+		t.Errorf("Invalid type name %v", typeName)
+	}
+	commandType, err := bus.GetCommandType(typeName)
+	if err != nil || commandType != reflect.TypeOf(createUserCommand{}) {
+		t.Errorf("Command type must be createUserCommand got %v, error %v", commandType, err)
+	}
+	if _, err := bus.GetCommandType("unknown"); err == nil {
+		t.Errorf("Unknown type name must return error")
+	}
+}
+
+func TestCommandBus_MarshalCommand(t *testing.T) {
+	bus := NewCommandBus(handlers.NewCommandHandlerExecutor())
+	bus.Register(reflect.TypeOf(createUserCommand{}), &createUserHandler{})
+
+	command := createUserCommand{Name: "adriano"}
+	command.CommandId = uuid.New()
+	typeName, payload, err := MarshalCommand(command)
+	if err != nil {
+		t.Fatalf("Error marshalling command\nError: %s", err)
+	}
+
+	restored, err := UnmarshalCommand(bus, typeName, payload)
+	if err != nil {
+		t.Fatalf("Error unmarshalling command\nError: %s", err)
+	}
+	restoredCommand, ok := restored.(*createUserCommand)
+	if !ok || restoredCommand.Name != "adriano" || restoredCommand.CommandId != command.CommandId {
+		t.Errorf("Restored command is different of the original got %v", restored)
+	}
+}
@@ -0,0 +1,37 @@
+package bus
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"reflect"
+)
+
+// MarshalCommand serializes the command to JSON together with the type name needed to restore it
+func MarshalCommand(command commands.Command) (string, []byte, error) {
+	if command == nil {
+		return "", nil, fmt.Errorf("The command must not be null")
+	}
+	payload, err := json.Marshal(command)
+	if err != nil {
+		return "", nil, err
+	}
+	return CommandTypeName(reflect.TypeOf(command)), payload, nil
+}
+
+// UnmarshalCommand restores a command of a registered type, the command is always returned as a pointer
+func UnmarshalCommand(bus CommandBusInterface, typeName string, payload []byte) (commands.Command, error) {
+	commandType, err := bus.GetCommandType(typeName)
+	if err != nil {
+		return nil, err
+	}
+	value := reflect.New(commandType)
+	if err := json.Unmarshal(payload, value.Interface()); err != nil {
+		return nil, err
+	}
+	command, ok := value.Interface().(commands.Command)
+	if !ok {
+		return nil, fmt.Errorf("The type %s doesn`t implement commands.Command", typeName)
+	}
+	return command, nil
+}
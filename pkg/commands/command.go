@@ -5,13 +5,58 @@ import (
 	"time"
 )
 
+type ResultCode int
+
+const (
+	SuccessCodeResult ResultCode = iota
+	InternalErrorCodeResult
+	ValidationFailedCodeResult
+	NotFoundCodeResult
+	ConflictCodeResult
+	UnauthorizedCodeResult
+	ForbiddenCodeResult
+)
+
 type CommandBase struct {
-	CommandId uuid.UUID
+	CommandId          uuid.UUID
 	CommandPublishDate time.Time
 }
 
+type Command interface {
+	GetCommandBase() CommandBase
+}
+
+func (command CommandBase) GetCommandBase() CommandBase {
+	return command
+}
+
 type CommandResult struct {
-	CodeResult int
-	ResultData interface{}
+	CodeResult  ResultCode
+	ResultData  interface{}
 	ContextData map[string]interface{}
+	Error       error
+	Interrupted bool
+}
+
+func NewCommandResult() CommandResult {
+	return CommandResult{
+		CodeResult:  SuccessCodeResult,
+		ContextData: make(map[string]interface{}),
+	}
+}
+
+func (result CommandResult) IsSuccess() bool {
+	return result.CodeResult == SuccessCodeResult && result.Error == nil
+}
+
+// Interrupt stops the executor pipeline returning this result as the final one
+func (result CommandResult) Interrupt() CommandResult {
+	result.Interrupted = true
+	return result
+}
+
+func (result CommandResult) Fail(codeResult ResultCode, err error) CommandResult {
+	result.CodeResult = codeResult
+	result.Error = err
+	return result
 }
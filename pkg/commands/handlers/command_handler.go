@@ -1,18 +1,121 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/commands/afterexecute"
 	"github.com/drprado2/go-backend-framework/pkg/commands/beforeexecute"
+	"github.com/drprado2/go-backend-framework/pkg/commands/onfail"
 )
 
 type CommandHandlerInterface interface {
-	Handle(command commands.CommandBase, result commands.CommandResult) commands.CommandResult
+	Handle(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult
 }
 
 type CommandHandlerExecutorInterface interface {
-	ExecuteCommand(command *commands.CommandBase, handler *CommandHandlerInterface) commands.CommandResult
+	ExecuteCommand(ctx context.Context, command commands.Command, handler CommandHandlerInterface) commands.CommandResult
 }
 
 type CommandHandlerExecutor struct {
-	beforeExecuteActions []*beforeexecute.Interface
-}
\ No newline at end of file
+	beforeExecuteActions []beforeexecute.Interface
+	afterExecuteActions  []afterexecute.Interface
+	onFailActions        []onfail.Interface
+}
+
+func NewCommandHandlerExecutor() *CommandHandlerExecutor {
+	return &CommandHandlerExecutor{
+		beforeExecuteActions: make([]beforeexecute.Interface, 0),
+		afterExecuteActions:  make([]afterexecute.Interface, 0),
+		onFailActions:        make([]onfail.Interface, 0),
+	}
+}
+
+func (executor *CommandHandlerExecutor) AddBeforeExecuteAction(actions ...beforeexecute.Interface) *CommandHandlerExecutor {
+	executor.beforeExecuteActions = append(executor.beforeExecuteActions, actions...)
+	return executor
+}
+
+func (executor *CommandHandlerExecutor) AddAfterExecuteAction(actions ...afterexecute.Interface) *CommandHandlerExecutor {
+	executor.afterExecuteActions = append(executor.afterExecuteActions, actions...)
+	return executor
+}
+
+func (executor *CommandHandlerExecutor) AddOnFailAction(actions ...onfail.Interface) *CommandHandlerExecutor {
+	executor.onFailActions = append(executor.onFailActions, actions...)
+	return executor
+}
+
+// ExecuteCommand runs the before execute actions, the handler and the after execute actions in order,
+// any failed result is routed to the on fail actions and any interrupted result is returned immediately
+func (executor *CommandHandlerExecutor) ExecuteCommand(ctx context.Context, command commands.Command, handler CommandHandlerInterface) commands.CommandResult {
+	result := commands.NewCommandResult()
+
+	for _, action := range executor.beforeExecuteActions {
+		result = executor.runStage(func() commands.CommandResult {
+			return action.Execute(ctx, command, result)
+		}, result)
+		if result.Interrupted {
+			return result
+		}
+		if !result.IsSuccess() {
+			return executor.onFail(ctx, command, result)
+		}
+	}
+
+	result = executor.runStage(func() commands.CommandResult {
+		return handler.Handle(ctx, command, result)
+	}, result)
+	if result.Interrupted {
+		return result
+	}
+	if !result.IsSuccess() {
+		return executor.onFail(ctx, command, result)
+	}
+
+	for _, action := range executor.afterExecuteActions {
+		result = executor.runStage(func() commands.CommandResult {
+			return action.Execute(ctx, command, result)
+		}, result)
+		if result.Interrupted {
+			return result
+		}
+		if !result.IsSuccess() {
+			return executor.onFail(ctx, command, result)
+		}
+	}
+
+	return result
+}
+
+func (executor *CommandHandlerExecutor) onFail(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult {
+	for _, action := range executor.onFailActions {
+		result = executor.runStage(func() commands.CommandResult {
+			return action.Execute(ctx, command, result)
+		}, result)
+		if result.Interrupted {
+			return result
+		}
+	}
+	return result
+}
+
+// runStage turns a panic inside a stage into a failed result so it can be handled by the on fail actions,
+// the panic of an on fail action keeps the error of the failure it was handling
+func (executor *CommandHandlerExecutor) runStage(stage func() commands.CommandResult, current commands.CommandResult) (result commands.CommandResult) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err := fmt.Errorf("Panic executing command: %v", recovered)
+			if current.Error != nil {
+				err = errors.Join(current.Error, err)
+			}
+			result = current.Fail(commands.InternalErrorCodeResult, err)
+		}
+	}()
+	result = stage()
+	if result.ContextData == nil {
+		result.ContextData = current.ContextData
+	}
+	return result
+}
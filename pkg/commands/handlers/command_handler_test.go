@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"strings"
+	"testing"
+)
+
+type stageMock struct {
+	name     string
+	calls    *[]string
+	execMock func(result commands.CommandResult) commands.CommandResult
+}
+
+func (mock *stageMock) Execute(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult {
+	*mock.calls = append(*mock.calls, mock.name)
+	if mock.execMock != nil {
+		return mock.execMock(result)
+	}
+	return result
+}
+
+func (mock *stageMock) Handle(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult {
+	return mock.Execute(ctx, command, result)
+}
+
+func assertCalls(t *testing.T, calls []string, expected ...string) {
+	if len(calls) != len(expected) {
+		t.Fatalf("Calls must be %v got %v", expected, calls)
+	}
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Fatalf("Calls must be %v got %v", expected, calls)
+		}
+	}
+}
+
+func TestCommandHandlerExecutor_ExecuteCommandInOrder(t *testing.T) {
+	calls := make([]string, 0)
+	executor := NewCommandHandlerExecutor().
+		AddBeforeExecuteAction(&stageMock{name: "before1", calls: &calls}, &stageMock{name: "before2", calls: &calls}).
+		AddAfterExecuteAction(&stageMock{name: "after", calls: &calls}).
+		AddOnFailAction(&stageMock{name: "onfail", calls: &calls})
+	handler := &stageMock{name: "handler", calls: &calls, execMock: func(result commands.CommandResult) commands.CommandResult {
+		result.ResultData = 10
+		return result
+	}}
+
+	result := executor.ExecuteCommand(context.Background(), commands.CommandBase{}, handler)
+
+	assertCalls(t, calls, "before1", "before2", "handler", "after")
+	if !result.IsSuccess() || result.ResultData != 10 {
+		t.Errorf("Result must be success with data 10 got %v", result)
+	}
+}
+
+func TestCommandHandlerExecutor_ExecuteCommandWithHandlerFail(t *testing.T) {
+	calls := make([]string, 0)
+	executor := NewCommandHandlerExecutor().
+		AddAfterExecuteAction(&stageMock{name: "after", calls: &calls}).
+		AddOnFailAction(&stageMock{name: "onfail1", calls: &calls}, &stageMock{name: "onfail2", calls: &calls})
+	handler := &stageMock{name: "handler", calls: &calls, execMock: func(result commands.CommandResult) commands.CommandResult {
+		return result.Fail(commands.InternalErrorCodeResult, errors.New("handler error"))
+	}}
+
+	result := executor.ExecuteCommand(context.Background(), commands.CommandBase{}, handler)
+
+	assertCalls(t, calls, "handler", "onfail1", "onfail2")
+	if result.IsSuccess() || result.Error == nil || result.Error.Error() != "handler error" {
+		t.Errorf("Result must fail with handler error got %v", result)
+	}
+}
+
+func TestCommandHandlerExecutor_ExecuteCommandWithHandlerPanic(t *testing.T) {
+	calls := make([]string, 0)
+	executor := NewCommandHandlerExecutor().
+		AddOnFailAction(&stageMock{name: "onfail", calls: &calls})
+	handler := &stageMock{name: "handler", calls: &calls, execMock: func(result commands.CommandResult) commands.CommandResult {
+		panic("unexpected")
+	}}
+
+	result := executor.ExecuteCommand(context.Background(), commands.CommandBase{}, handler)
+
+	assertCalls(t, calls, "handler", "onfail")
+	if result.CodeResult != commands.InternalErrorCodeResult || result.Error == nil {
+		t.Errorf("Result must fail after a panic got %v", result)
+	}
+}
+
+func TestCommandHandlerExecutor_ExecuteCommandWithOnFailPanic(t *testing.T) {
+	calls := make([]string, 0)
+	executor := NewCommandHandlerExecutor().
+		AddOnFailAction(&stageMock{name: "onfail1", calls: &calls, execMock: func(result commands.CommandResult) commands.CommandResult {
+			panic("compensation unavailable")
+		}}, &stageMock{name: "onfail2", calls: &calls})
+	handler := &stageMock{name: "handler", calls: &calls, execMock: func(result commands.CommandResult) commands.CommandResult {
+		return result.Fail(commands.ConflictCodeResult, errors.New("handler error"))
+	}}
+
+	result := executor.ExecuteCommand(context.Background(), commands.CommandBase{}, handler)
+
+	assertCalls(t, calls, "handler", "onfail1", "onfail2")
+	if result.CodeResult != commands.InternalErrorCodeResult || result.Error == nil ||
+		!strings.Contains(result.Error.Error(), "handler error") || !strings.Contains(result.Error.Error(), "compensation unavailable") {
+		t.Errorf("Result must fail with the handler error and the panic got %v", result)
+	}
+}
+
+func TestCommandHandlerExecutor_ExecuteCommandWithBeforeFail(t *testing.T) {
+	calls := make([]string, 0)
+	executor := NewCommandHandlerExecutor().
+		AddBeforeExecuteAction(&stageMock{name: "before", calls: &calls, execMock: func(result commands.CommandResult) commands.CommandResult {
+			result.CodeResult = 3
+			return result
+		}}).
+		AddOnFailAction(&stageMock{name: "onfail", calls: &calls})
+	handler := &stageMock{name: "handler", calls: &calls}
+
+	result := executor.ExecuteCommand(context.Background(), commands.CommandBase{}, handler)
+
+	assertCalls(t, calls, "before", "onfail")
+	if result.CodeResult != 3 {
+		t.Errorf("Code result must be 3 got %v", result.CodeResult)
+	}
+}
+
+func TestCommandHandlerExecutor_ExecuteCommandWithInterrupt(t *testing.T) {
+	calls := make([]string, 0)
+	executor := NewCommandHandlerExecutor().
+		AddBeforeExecuteAction(&stageMock{name: "before", calls: &calls, execMock: func(result commands.CommandResult) commands.CommandResult {
+			result.ResultData = "cached"
+			return result.Interrupt()
+		}}).
+		AddAfterExecuteAction(&stageMock{name: "after", calls: &calls}).
+		AddOnFailAction(&stageMock{name: "onfail", calls: &calls})
+	handler := &stageMock{name: "handler", calls: &calls}
+
+	result := executor.ExecuteCommand(context.Background(), commands.CommandBase{}, handler)
+
+	assertCalls(t, calls, "before")
+	if !result.Interrupted || result.ResultData != "cached" {
+		t.Errorf("Result must be interrupted with cached data got %v", result)
+	}
+}
+
+func TestCommandHandlerExecutor_ExecuteCommandWithAfterFail(t *testing.T) {
+	calls := make([]string, 0)
+	executor := NewCommandHandlerExecutor().
+		AddAfterExecuteAction(&stageMock{name: "after1", calls: &calls, execMock: func(result commands.CommandResult) commands.CommandResult {
+			return result.Fail(commands.InternalErrorCodeResult, errors.New("after error"))
+		}}, &stageMock{name: "after2", calls: &calls}).
+		AddOnFailAction(&stageMock{name: "onfail", calls: &calls})
+	handler := &stageMock{name: "handler", calls: &calls}
+
+	result := executor.ExecuteCommand(context.Background(), commands.CommandBase{}, handler)
+
+	assertCalls(t, calls, "handler", "after1", "onfail")
+	if result.IsSuccess() {
+		t.Errorf("Result must fail got %v", result)
+	}
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/notifications"
+	"github.com/drprado2/go-backend-framework/pkg/storage"
+)
+
+// TransactionalCommandHandler wraps a handler in a transaction of the unit of work,
+// the wrapped handler must use the same unit of work to get the transaction scoped database
+type TransactionalCommandHandler struct {
+	unitOfWork  storage.UnitOfWorkInterface
+	notificator notifications.NotificatorInterface
+	handler     CommandHandlerInterface
+}
+
+func NewTransactionalCommandHandler(
+	unitOfWork storage.UnitOfWorkInterface,
+	notificator notifications.NotificatorInterface,
+	handler CommandHandlerInterface,
+) *TransactionalCommandHandler {
+	return &TransactionalCommandHandler{
+		unitOfWork:  unitOfWork,
+		notificator: notificator,
+		handler:     handler,
+	}
+}
+
+func (decorator *TransactionalCommandHandler) Handle(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult {
+	if err := decorator.unitOfWork.BeginTran(); err != nil {
+		return result.Fail(commands.InternalErrorCodeResult, fmt.Errorf("Error beginning transaction\nError: %w", err))
+	}
+
+	finished := false
+	defer func() {
+		if !finished {
+			decorator.unitOfWork.Rollback()
+		}
+	}()
+
+	result = decorator.handler.Handle(ctx, command, result)
+	finished = true
+
+	if !result.IsSuccess() || decorator.hasNotifications() {
+		if err := decorator.unitOfWork.Rollback(); err != nil {
+			return result.Fail(commands.InternalErrorCodeResult, fmt.Errorf("Error on rollback\nError: %w", err))
+		}
+		if result.IsSuccess() {
+			result.CodeResult = commands.ValidationFailedCodeResult
+		}
+		return result
+	}
+
+	if err := decorator.unitOfWork.Commit(); err != nil {
+		return result.Fail(commands.InternalErrorCodeResult, fmt.Errorf("Error on commit\nError: %w", err))
+	}
+	return result
+}
+
+func (decorator *TransactionalCommandHandler) hasNotifications() bool {
+	return decorator.notificator != nil && decorator.notificator.HasNotification()
+}
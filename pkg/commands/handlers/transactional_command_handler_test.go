@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/notifications"
+	"github.com/drprado2/go-backend-framework/pkg/storage"
+	"testing"
+)
+
+func newUnitOfWorkMock(calls *[]string) *storage.UnitOfWorkMock {
+	return &storage.UnitOfWorkMock{
+		BeginTranMock: func() error {
+			*calls = append(*calls, "begin")
+			return nil
+		},
+		CommitMock: func() error {
+			*calls = append(*calls, "commit")
+			return nil
+		},
+		RollbackMock: func() error {
+			*calls = append(*calls, "rollback")
+			return nil
+		},
+	}
+}
+
+func TestTransactionalCommandHandler_Commit(t *testing.T) {
+	calls := make([]string, 0)
+	handler := NewTransactionalCommandHandler(newUnitOfWorkMock(&calls), notifications.NewNotificator(), &stageMock{name: "handler", calls: &calls})
+
+	result := NewCommandHandlerExecutor().ExecuteCommand(context.Background(), commands.CommandBase{}, handler)
+
+	assertCalls(t, calls, "begin", "handler", "commit")
+	if !result.IsSuccess() {
+		t.Errorf("Result must be success got %v", result)
+	}
+}
+
+func TestTransactionalCommandHandler_RollbackOnFail(t *testing.T) {
+	calls := make([]string, 0)
+	handler := NewTransactionalCommandHandler(newUnitOfWorkMock(&calls), nil, &stageMock{name: "handler", calls: &calls, execMock: func(result commands.CommandResult) commands.CommandResult {
+		return result.Fail(commands.InternalErrorCodeResult, errors.New("handler error"))
+	}})
+
+	result := NewCommandHandlerExecutor().ExecuteCommand(context.Background(), commands.CommandBase{}, handler)
+
+	assertCalls(t, calls, "begin", "handler", "rollback")
+	if result.IsSuccess() {
+		t.Errorf("Result must fail got %v", result)
+	}
+}
+
+func TestTransactionalCommandHandler_RollbackOnNotification(t *testing.T) {
+	calls := make([]string, 0)
+	notificator := notifications.NewNotificator()
+	handler := NewTransactionalCommandHandler(newUnitOfWorkMock(&calls), notificator, &stageMock{name: "handler", calls: &calls, execMock: func(result commands.CommandResult) commands.CommandResult {
+		notificator.AddNotification(notifications.Notification{Message: "invalid name", Code: "name"})
+		return result
+	}})
+
+	result := NewCommandHandlerExecutor().ExecuteCommand(context.Background(), commands.CommandBase{}, handler)
+
+	assertCalls(t, calls, "begin", "handler", "rollback")
+	if result.IsSuccess() {
+		t.Errorf("Result must fail got %v", result)
+	}
+}
+
+func TestTransactionalCommandHandler_RollbackOnPanic(t *testing.T) {
+	calls := make([]string, 0)
+	handler := NewTransactionalCommandHandler(newUnitOfWorkMock(&calls), nil, &stageMock{name: "handler", calls: &calls, execMock: func(result commands.CommandResult) commands.CommandResult {
+		panic("unexpected")
+	}})
+
+	result := NewCommandHandlerExecutor().ExecuteCommand(context.Background(), commands.CommandBase{}, handler)
+
+	assertCalls(t, calls, "begin", "handler", "rollback")
+	if result.IsSuccess() || result.Error == nil {
+		t.Errorf("Result must fail after a panic got %v", result)
+	}
+}
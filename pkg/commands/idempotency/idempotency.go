@@ -0,0 +1,60 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/google/uuid"
+)
+
+const DuplicatedCommandContextKey = "duplicatedCommand"
+
+// StoreInterface serializes the executions of the same command id, Acquire blocks while
+// another execution holds the entry of the command
+type StoreInterface interface {
+	Acquire(ctx context.Context, commandId uuid.UUID) (EntryInterface, error)
+}
+
+type EntryInterface interface {
+	Result() (commands.CommandResult, bool)
+	Complete(ctx context.Context, result commands.CommandResult) error
+	Release() error
+}
+
+type serializedResult struct {
+	CodeResult  commands.ResultCode
+	ResultData  interface{}
+	ContextData map[string]interface{}
+	Error       string
+}
+
+// MarshalResult serializes the result to JSON, the ResultData is restored as the generic JSON representation
+func MarshalResult(result commands.CommandResult) ([]byte, error) {
+	serialized := serializedResult{
+		CodeResult:  result.CodeResult,
+		ResultData:  result.ResultData,
+		ContextData: result.ContextData,
+	}
+	if result.Error != nil {
+		serialized.Error = result.Error.Error()
+	}
+	return json.Marshal(serialized)
+}
+
+func UnmarshalResult(data []byte) (commands.CommandResult, error) {
+	serialized := serializedResult{}
+	if err := json.Unmarshal(data, &serialized); err != nil {
+		return commands.CommandResult{}, err
+	}
+	result := commands.NewCommandResult()
+	result.CodeResult = serialized.CodeResult
+	result.ResultData = serialized.ResultData
+	if serialized.ContextData != nil {
+		result.ContextData = serialized.ContextData
+	}
+	if serialized.Error != "" {
+		result.Error = errors.New(serialized.Error)
+	}
+	return result, nil
+}
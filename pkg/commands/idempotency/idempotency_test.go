@@ -0,0 +1,115 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/google/uuid"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type storeMock struct {
+	mutex       sync.Mutex
+	locks       map[uuid.UUID]*sync.Mutex
+	results     map[uuid.UUID][]byte
+	completeErr error
+}
+
+func newStoreMock() *storeMock {
+	return &storeMock{
+		locks:   make(map[uuid.UUID]*sync.Mutex),
+		results: make(map[uuid.UUID][]byte),
+	}
+}
+
+func (store *storeMock) Acquire(ctx context.Context, commandId uuid.UUID) (EntryInterface, error) {
+	store.mutex.Lock()
+	lock, ok := store.locks[commandId]
+	if !ok {
+		lock = &sync.Mutex{}
+		store.locks[commandId] = lock
+	}
+	store.mutex.Unlock()
+
+	lock.Lock()
+	return &entryMock{store: store, lock: lock, commandId: commandId}, nil
+}
+
+type entryMock struct {
+	store     *storeMock
+	lock      *sync.Mutex
+	commandId uuid.UUID
+	released  bool
+}
+
+func (entry *entryMock) Result() (commands.CommandResult, bool) {
+	entry.store.mutex.Lock()
+	defer entry.store.mutex.Unlock()
+	data, ok := entry.store.results[entry.commandId]
+	if !ok {
+		return commands.CommandResult{}, false
+	}
+	result, _ := UnmarshalResult(data)
+	return result, true
+}
+
+func (entry *entryMock) Complete(ctx context.Context, result commands.CommandResult) error {
+	if entry.store.completeErr != nil {
+		return entry.store.completeErr
+	}
+	data, err := MarshalResult(result)
+	if err != nil {
+		return err
+	}
+	entry.store.mutex.Lock()
+	entry.store.results[entry.commandId] = data
+	entry.store.mutex.Unlock()
+	return nil
+}
+
+func (entry *entryMock) Release() error {
+	if !entry.released {
+		entry.released = true
+		entry.lock.Unlock()
+	}
+	return nil
+}
+
+type countHandler struct {
+	calls      int32
+	err        error
+	codeResult commands.ResultCode
+}
+
+func (handler *countHandler) Handle(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult {
+	calls := atomic.AddInt32(&handler.calls, 1)
+	if handler.err != nil {
+		return result.Fail(commands.InternalErrorCodeResult, handler.err)
+	}
+	if handler.codeResult != commands.SuccessCodeResult {
+		result.CodeResult = handler.codeResult
+		return result
+	}
+	result.ResultData = float64(calls)
+	return result
+}
+
+func TestMarshalResult(t *testing.T) {
+	result := commands.NewCommandResult().Fail(3, errors.New("invalid"))
+	result.ResultData = "data"
+	result.ContextData["key"] = "value"
+
+	data, err := MarshalResult(result)
+	if err != nil {
+		t.Fatalf("Error marshalling result\nError: %s", err)
+	}
+	restored, err := UnmarshalResult(data)
+	if err != nil {
+		t.Fatalf("Error unmarshalling result\nError: %s", err)
+	}
+	if restored.CodeResult != 3 || restored.ResultData != "data" || restored.ContextData["key"] != "value" || restored.Error.Error() != "invalid" {
+		t.Errorf("Restored result is different of the original got %v", restored)
+	}
+}
@@ -0,0 +1,56 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/commands/handlers"
+	"github.com/google/uuid"
+)
+
+// IdempotentCommandHandler checks and records the command id inside the transaction of the
+// TransactionalCommandHandler that wraps it, the record is committed or rolled back together with the
+// changes of the handler so a committed command never runs again
+//
+//	handler := handlers.NewTransactionalCommandHandler(unitOfWork, notificator,
+//		idempotency.NewIdempotentCommandHandler(postgres.NewIdempotencyStore(unitOfWork), createUserHandler))
+type IdempotentCommandHandler struct {
+	store   StoreInterface
+	handler handlers.CommandHandlerInterface
+}
+
+func NewIdempotentCommandHandler(store StoreInterface, handler handlers.CommandHandlerInterface) *IdempotentCommandHandler {
+	return &IdempotentCommandHandler{
+		store:   store,
+		handler: handler,
+	}
+}
+
+// Handle only records successful results, a failed result is rolled back by the transaction so the
+// command can run again. A failure recording the result fails the command to roll back its changes
+func (decorator *IdempotentCommandHandler) Handle(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult {
+	commandId := command.GetCommandBase().CommandId
+	if commandId == uuid.Nil {
+		return decorator.handler.Handle(ctx, command, result)
+	}
+
+	entry, err := decorator.store.Acquire(ctx, commandId)
+	if err != nil {
+		return result.Fail(commands.InternalErrorCodeResult, fmt.Errorf("Error acquiring the command %s\nError: %w", commandId, err))
+	}
+	defer entry.Release()
+
+	if stored, found := entry.Result(); found {
+		stored.ContextData[DuplicatedCommandContextKey] = true
+		return stored
+	}
+
+	result = decorator.handler.Handle(ctx, command, result)
+	if !result.IsSuccess() {
+		return result
+	}
+	if err := entry.Complete(ctx, result); err != nil {
+		return result.Fail(commands.InternalErrorCodeResult, fmt.Errorf("Error recording the command %s\nError: %w", commandId, err))
+	}
+	return result
+}
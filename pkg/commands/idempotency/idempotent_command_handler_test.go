@@ -0,0 +1,88 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/commands/handlers"
+	"github.com/google/uuid"
+	"sync"
+	"testing"
+)
+
+func TestIdempotentCommandHandler_DuplicatedCommand(t *testing.T) {
+	handler := &countHandler{}
+	idempotentHandler := NewIdempotentCommandHandler(newStoreMock(), handler)
+	command := commands.CommandBase{CommandId: uuid.New()}
+
+	first := handlers.NewCommandHandlerExecutor().ExecuteCommand(context.Background(), command, idempotentHandler)
+	second := handlers.NewCommandHandlerExecutor().ExecuteCommand(context.Background(), command, idempotentHandler)
+
+	if handler.calls != 1 {
+		t.Errorf("Handler must be called once got %v", handler.calls)
+	}
+	if first.ResultData != float64(1) || second.ResultData != float64(1) {
+		t.Errorf("Both results must have data 1 got %v and %v", first.ResultData, second.ResultData)
+	}
+	if second.ContextData[DuplicatedCommandContextKey] != true {
+		t.Errorf("Second result must be marked as duplicated got %v", second.ContextData)
+	}
+}
+
+func TestIdempotentCommandHandler_ConcurrentDuplicatedCommand(t *testing.T) {
+	handler := &countHandler{}
+	idempotentHandler := NewIdempotentCommandHandler(newStoreMock(), handler)
+	command := commands.CommandBase{CommandId: uuid.New()}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			handlers.NewCommandHandlerExecutor().ExecuteCommand(context.Background(), command, idempotentHandler)
+		}()
+	}
+	wg.Wait()
+
+	if handler.calls != 1 {
+		t.Errorf("Handler must be called once got %v", handler.calls)
+	}
+}
+
+func TestIdempotentCommandHandler_FailureIsNotStored(t *testing.T) {
+	handler := &countHandler{err: errors.New("connection reset")}
+	idempotentHandler := NewIdempotentCommandHandler(newStoreMock(), handler)
+	command := commands.CommandBase{CommandId: uuid.New()}
+
+	handlers.NewCommandHandlerExecutor().ExecuteCommand(context.Background(), command, idempotentHandler)
+	handlers.NewCommandHandlerExecutor().ExecuteCommand(context.Background(), command, idempotentHandler)
+
+	if handler.calls != 2 {
+		t.Errorf("Handler must be called twice got %v", handler.calls)
+	}
+}
+
+func TestIdempotentCommandHandler_ValidationFailureIsNotStored(t *testing.T) {
+	handler := &countHandler{codeResult: commands.ValidationFailedCodeResult}
+	idempotentHandler := NewIdempotentCommandHandler(newStoreMock(), handler)
+	command := commands.CommandBase{CommandId: uuid.New()}
+
+	handlers.NewCommandHandlerExecutor().ExecuteCommand(context.Background(), command, idempotentHandler)
+	handlers.NewCommandHandlerExecutor().ExecuteCommand(context.Background(), command, idempotentHandler)
+
+	if handler.calls != 2 {
+		t.Errorf("Handler must be called twice got %v", handler.calls)
+	}
+}
+
+func TestIdempotentCommandHandler_CompleteErrorFailsCommand(t *testing.T) {
+	store := newStoreMock()
+	store.completeErr = errors.New("insert failed")
+	idempotentHandler := NewIdempotentCommandHandler(store, &countHandler{})
+
+	result := handlers.NewCommandHandlerExecutor().ExecuteCommand(context.Background(), commands.CommandBase{CommandId: uuid.New()}, idempotentHandler)
+
+	if result.IsSuccess() || !errors.Is(result.Error, store.completeErr) {
+		t.Errorf("Result must fail with the complete error so the transaction rolls back got %v", result)
+	}
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/commands/idempotency"
+	"github.com/drprado2/go-backend-framework/pkg/storage"
+	"github.com/google/uuid"
+)
+
+const (
+	createTableQuery = `create table if not exists command_idempotency (
+		command_id uuid primary key,
+		result jsonb not null,
+		created_at timestamptz not null default now()
+	)`
+	lockQuery   = `select pg_advisory_xact_lock($1, $2)`
+	selectQuery = `select result from command_idempotency where command_id = $1`
+	insertQuery = `insert into command_idempotency (command_id, result) values ($1, $2)`
+)
+
+// IdempotencyStore keeps the command results in the transaction of the unit of work shared with the
+// handler, it must be used by an IdempotentCommandHandler wrapped by a TransactionalCommandHandler
+type IdempotencyStore struct {
+	unitOfWork storage.UnitOfWorkInterface
+}
+
+func NewIdempotencyStore(unitOfWork storage.UnitOfWorkInterface) *IdempotencyStore {
+	return &IdempotencyStore{
+		unitOfWork: unitOfWork,
+	}
+}
+
+func (store *IdempotencyStore) CreateTable(ctx context.Context) error {
+	_, err := store.unitOfWork.GetDatabase().ExecContext(ctx, createTableQuery)
+	return err
+}
+
+// lockKeys folds the 128 bits of the command id into the two 32 bits keys of the advisory lock
+func lockKeys(commandId uuid.UUID) (int32, int32) {
+	first := binary.BigEndian.Uint32(commandId[0:4]) ^ binary.BigEndian.Uint32(commandId[8:12])
+	second := binary.BigEndian.Uint32(commandId[4:8]) ^ binary.BigEndian.Uint32(commandId[12:16])
+	return int32(first), int32(second)
+}
+
+// Acquire holds an advisory lock of the command id until the transaction of the unit of work ends
+func (store *IdempotencyStore) Acquire(ctx context.Context, commandId uuid.UUID) (idempotency.EntryInterface, error) {
+	tx, inTransaction := store.unitOfWork.GetDatabase().(storage.TransactionInterface)
+	if !inTransaction {
+		return nil, errors.New("the idempotency store must run inside a transaction of the unit of work")
+	}
+
+	first, second := lockKeys(commandId)
+	if _, err := tx.ExecContext(ctx, lockQuery, first, second); err != nil {
+		return nil, err
+	}
+
+	entry := &idempotencyEntry{
+		tx:        tx,
+		commandId: commandId,
+	}
+
+	var data []byte
+	err := tx.QueryRowContext(ctx, selectQuery, commandId).Scan(&data)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err == nil {
+		result, err := idempotency.UnmarshalResult(data)
+		if err != nil {
+			return nil, err
+		}
+		entry.result = &result
+	}
+	return entry, nil
+}
+
+type idempotencyEntry struct {
+	tx        storage.TransactionInterface
+	commandId uuid.UUID
+	result    *commands.CommandResult
+}
+
+func (entry *idempotencyEntry) Result() (commands.CommandResult, bool) {
+	if entry.result == nil {
+		return commands.CommandResult{}, false
+	}
+	return *entry.result, true
+}
+
+// Complete inserts the result in the transaction, it is committed by the unit of work
+func (entry *idempotencyEntry) Complete(ctx context.Context, result commands.CommandResult) error {
+	data, err := idempotency.MarshalResult(result)
+	if err != nil {
+		return err
+	}
+	_, err = entry.tx.ExecContext(ctx, insertQuery, entry.commandId, data)
+	return err
+}
+
+// Release does nothing, the lock is released by the commit or rollback of the unit of work
+func (entry *idempotencyEntry) Release() error {
+	return nil
+}
@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/commands/handlers"
+	"github.com/drprado2/go-backend-framework/pkg/commands/idempotency"
+	"github.com/drprado2/go-backend-framework/pkg/storage"
+	storagepostgres "github.com/drprado2/go-backend-framework/pkg/storage/postgres"
+	"github.com/drprado2/go-backend-framework/pkg/tests/testutilities"
+	"github.com/google/uuid"
+	"sync"
+	"testing"
+)
+
+type idempotencyStoreFixture struct {
+	fullDB storage.FullDatabaseInterface
+}
+
+func (fixture *idempotencyStoreFixture) setup(t *testing.T) {
+	database := testutilities.NewTestDatabase(t)
+	var err error
+	if fixture.fullDB, err = storagepostgres.NewDatabaseFactory(database.ConnectionString).GetDB(); err != nil {
+		t.Fatal("Error in setup", err)
+	}
+	if err := NewIdempotencyStore(storagepostgres.NewUnitOfWork(fixture.fullDB)).CreateTable(context.Background()); err != nil {
+		t.Fatal("Error creating table", err)
+	}
+	if _, err := fixture.fullDB.Exec(`create table users (name varchar primary key)`); err != nil {
+		t.Fatal("Error creating table", err)
+	}
+}
+
+func (fixture *idempotencyStoreFixture) teardown(t *testing.T) {
+	fixture.fullDB.Close()
+}
+
+// execute runs the command with its own unit of work shared by the transaction, the store and the handler
+func (fixture *idempotencyStoreFixture) execute(command commands.Command, handler *insertUserHandler) commands.CommandResult {
+	unitOfWork := storagepostgres.NewUnitOfWork(fixture.fullDB)
+	transactional := handlers.NewTransactionalCommandHandler(unitOfWork, nil,
+		idempotency.NewIdempotentCommandHandler(NewIdempotencyStore(unitOfWork), handler.withUnitOfWork(unitOfWork)))
+	return handlers.NewCommandHandlerExecutor().ExecuteCommand(context.Background(), command, transactional)
+}
+
+func (fixture *idempotencyStoreFixture) countRows(t *testing.T, table string) int {
+	var count int
+	if err := fixture.fullDB.QueryRow(`select count(*) from ` + table).Scan(&count); err != nil {
+		t.Fatal("Error counting rows", err)
+	}
+	return count
+}
+
+type insertUserCommand struct {
+	commands.CommandBase
+	Name string
+}
+
+type insertUserHandler struct {
+	mutex      sync.Mutex
+	calls      int
+	resultData interface{}
+}
+
+func (handler *insertUserHandler) withUnitOfWork(unitOfWork storage.UnitOfWorkInterface) handlers.CommandHandlerInterface {
+	return &unitOfWorkUserHandler{insertUserHandler: handler, unitOfWork: unitOfWork}
+}
+
+// unitOfWorkUserHandler inserts the user with the database of one execution
+type unitOfWorkUserHandler struct {
+	*insertUserHandler
+	unitOfWork storage.UnitOfWorkInterface
+}
+
+func (handler *unitOfWorkUserHandler) Handle(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult {
+	handler.mutex.Lock()
+	handler.calls++
+	handler.mutex.Unlock()
+	if _, err := handler.unitOfWork.GetDatabase().ExecContext(ctx, `insert into users values ($1)`, command.(*insertUserCommand).Name); err != nil {
+		return result.Fail(commands.InternalErrorCodeResult, err)
+	}
+	result.ResultData = handler.resultData
+	return result
+}
+
+func newInsertUserCommand(name string) *insertUserCommand {
+	return &insertUserCommand{CommandBase: commands.CommandBase{CommandId: uuid.New()}, Name: name}
+}
+
+func TestIdempotencyStore_DuplicatedCommand(t *testing.T) {
+	fixture := idempotencyStoreFixture{}
+	fixture.setup(t)
+	defer fixture.teardown(t)
+
+	handler := &insertUserHandler{resultData: "done"}
+	command := newInsertUserCommand("adriano")
+	if result := fixture.execute(command, handler); !result.IsSuccess() {
+		t.Fatalf("Command must succeed got %v", result)
+	}
+	result := fixture.execute(command, handler)
+
+	if handler.calls != 1 {
+		t.Errorf("Handler must be called once got %v", handler.calls)
+	}
+	if result.ResultData != "done" || result.ContextData[idempotency.DuplicatedCommandContextKey] != true {
+		t.Errorf("Duplicated result must be the stored one got %v", result)
+	}
+}
+
+func TestIdempotencyStore_ConcurrentDuplicatedCommand(t *testing.T) {
+	fixture := idempotencyStoreFixture{}
+	fixture.setup(t)
+	defer fixture.teardown(t)
+
+	handler := &insertUserHandler{resultData: "done"}
+	command := newInsertUserCommand("adriano")
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if result := fixture.execute(command, handler); !result.IsSuccess() {
+				t.Errorf("Command must succeed got %v", result)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if handler.calls != 1 {
+		t.Errorf("Handler must be called once got %v", handler.calls)
+	}
+	if count := fixture.countRows(t, "users"); count != 1 {
+		t.Errorf("Users must have 1 row got %v", count)
+	}
+}
+
+func TestIdempotencyStore_CompleteErrorRollsBackHandler(t *testing.T) {
+	fixture := idempotencyStoreFixture{}
+	fixture.setup(t)
+	defer fixture.teardown(t)
+
+	// a channel can not be serialized so recording the result fails after the handler inserted the user
+	handler := &insertUserHandler{resultData: make(chan int)}
+	result := fixture.execute(newInsertUserCommand("adriano"), handler)
+
+	if result.IsSuccess() {
+		t.Fatalf("Command must fail when the result is not recorded")
+	}
+	if count := fixture.countRows(t, "users"); count != 0 {
+		t.Errorf("User insert must be rolled back got %v rows", count)
+	}
+	if count := fixture.countRows(t, "command_idempotency"); count != 0 {
+		t.Errorf("Command must not be recorded got %v rows", count)
+	}
+}
+
+func TestIdempotencyStore_AcquireOutsideTransaction(t *testing.T) {
+	fixture := idempotencyStoreFixture{}
+	fixture.setup(t)
+	defer fixture.teardown(t)
+
+	store := NewIdempotencyStore(storagepostgres.NewUnitOfWork(fixture.fullDB))
+	if entry, err := store.Acquire(context.Background(), uuid.New()); entry != nil || err == nil {
+		t.Errorf("Acquire outside a transaction must fail got %v", entry)
+	}
+}
+
+func TestLockKeys(t *testing.T) {
+	commandId := uuid.MustParse("00000001-0000-0002-0000-000300000004")
+	if first, second := lockKeys(commandId); first != 1^3 || second != 2^4 {
+		t.Errorf("Lock keys must fold the halves of the id got %v and %v", first, second)
+	}
+}
@@ -0,0 +1,118 @@
+package journal
+
+import (
+	"context"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/commands/bus"
+	"github.com/drprado2/go-backend-framework/pkg/commands/handlers"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	JournalErrorContextKey = "journalError"
+)
+
+type Entry struct {
+	Sequence           int64
+	CommandId          uuid.UUID
+	CommandPublishDate time.Time
+	CommandType        string
+	Payload            []byte
+	CodeResult         commands.ResultCode
+	Duration           time.Duration
+	ExecutedAt         time.Time
+}
+
+// Filter selects the entries to read, the zero value of each field doesn`t filter
+type Filter struct {
+	FromPublishDate time.Time
+	ToPublishDate   time.Time
+	FromSequence    int64
+	ToSequence      int64
+	CommandTypes    []string
+	OnlySuccess     bool
+}
+
+// JournalInterface is append only, Read returns the entries ordered by sequence
+type JournalInterface interface {
+	Append(ctx context.Context, entry Entry) error
+	Read(ctx context.Context, filter Filter, readFunc func(entry Entry) error) error
+}
+
+type JournalingCommandExecutor struct {
+	journal  JournalInterface
+	executor handlers.CommandHandlerExecutorInterface
+}
+
+func NewJournalingCommandExecutor(journal JournalInterface, executor handlers.CommandHandlerExecutorInterface) *JournalingCommandExecutor {
+	return &JournalingCommandExecutor{
+		journal:  journal,
+		executor: executor,
+	}
+}
+
+func (executor *JournalingCommandExecutor) ExecuteCommand(ctx context.Context, command commands.Command, handler handlers.CommandHandlerInterface) commands.CommandResult {
+	start := time.Now()
+	result := executor.executor.ExecuteCommand(ctx, command, handler)
+	duration := time.Since(start)
+
+	err := executor.append(ctx, command, result, start, duration)
+	if err != nil {
+		if result.ContextData == nil {
+			result.ContextData = make(map[string]interface{})
+		}
+		result.ContextData[JournalErrorContextKey] = err.Error()
+	}
+	return result
+}
+
+func (executor *JournalingCommandExecutor) append(ctx context.Context, command commands.Command, result commands.CommandResult, start time.Time, duration time.Duration) error {
+	typeName, payload, err := bus.MarshalCommand(command)
+	if err != nil {
+		return err
+	}
+	base := command.GetCommandBase()
+	codeResult := result.CodeResult
+	if result.Error != nil && codeResult == commands.SuccessCodeResult {
+		codeResult = commands.InternalErrorCodeResult
+	}
+	return executor.journal.Append(ctx, Entry{
+		CommandId:          base.CommandId,
+		CommandPublishDate: base.CommandPublishDate,
+		CommandType:        typeName,
+		Payload:            payload,
+		CodeResult:         codeResult,
+		Duration:           duration,
+		ExecutedAt:         start,
+	})
+}
+
+type ReplayReport struct {
+	Replayed int
+	Failed   []uuid.UUID
+}
+
+// Replay dispatches again the journaled commands selected by the filter, the bus handlers must point to
+// the database that will receive the commands
+func Replay(ctx context.Context, journal JournalInterface, filter Filter, commandBus bus.CommandBusInterface) (ReplayReport, error) {
+	report := ReplayReport{
+		Failed: make([]uuid.UUID, 0),
+	}
+	err := journal.Read(ctx, filter, func(entry Entry) error {
+		command, err := bus.UnmarshalCommand(commandBus, entry.CommandType, entry.Payload)
+		if err != nil {
+			return err
+		}
+		result, err := commandBus.Dispatch(ctx, command)
+		if err != nil {
+			return err
+		}
+		report.Replayed++
+		if !result.IsSuccess() {
+			report.Failed = append(report.Failed, entry.CommandId)
+		}
+		return ctx.Err()
+	})
+	return report, err
+}
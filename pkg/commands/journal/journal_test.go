@@ -0,0 +1,111 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/commands/bus"
+	"github.com/drprado2/go-backend-framework/pkg/commands/handlers"
+	"github.com/google/uuid"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type journalMock struct {
+	entries []Entry
+}
+
+func (mock *journalMock) Append(ctx context.Context, entry Entry) error {
+	entry.Sequence = int64(len(mock.entries) + 1)
+	mock.entries = append(mock.entries, entry)
+	return nil
+}
+
+func (mock *journalMock) Read(ctx context.Context, filter Filter, readFunc func(entry Entry) error) error {
+	for _, entry := range mock.entries {
+		if filter.OnlySuccess && entry.CodeResult != commands.SuccessCodeResult {
+			continue
+		}
+		if err := readFunc(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type depositCommand struct {
+	commands.CommandBase
+	Amount int
+}
+
+type depositHandler struct {
+	balance int
+}
+
+func (handler *depositHandler) Handle(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult {
+	deposit := command.(*depositCommand)
+	if deposit.Amount < 0 {
+		return result.Fail(commands.InternalErrorCodeResult, errors.New("negative amount"))
+	}
+	handler.balance += deposit.Amount
+	return result
+}
+
+func newDepositCommand(amount int) *depositCommand {
+	return &depositCommand{
+		CommandBase: commands.CommandBase{CommandId: uuid.New(), CommandPublishDate: time.Now()},
+		Amount:      amount,
+	}
+}
+
+func newJournaledBus(journal JournalInterface, handler *depositHandler) *bus.CommandBus {
+	executor := NewJournalingCommandExecutor(journal, handlers.NewCommandHandlerExecutor())
+	commandBus := bus.NewCommandBus(executor)
+	commandBus.Register(reflect.TypeOf(depositCommand{}), handler)
+	return commandBus
+}
+
+func TestJournalingCommandExecutor_Append(t *testing.T) {
+	journal := &journalMock{}
+	commandBus := newJournaledBus(journal, &depositHandler{})
+
+	command := newDepositCommand(10)
+	commandBus.Dispatch(context.Background(), command)
+	commandBus.Dispatch(context.Background(), newDepositCommand(-1))
+
+	if len(journal.entries) != 2 {
+		t.Fatalf("Journal must have 2 entries got %v", len(journal.entries))
+	}
+	entry := journal.entries[0]
+	if entry.CommandId != command.CommandId || !entry.CommandPublishDate.Equal(command.CommandPublishDate) ||
+		entry.CommandType != bus.CommandTypeName(reflect.TypeOf(command)) || entry.CodeResult != commands.SuccessCodeResult {
+		t.Errorf("Invalid journal entry %v", entry)
+	}
+	if journal.entries[1].CodeResult != commands.InternalErrorCodeResult {
+		t.Errorf("Failed command must be journaled with the fail code result got %v", journal.entries[1].CodeResult)
+	}
+}
+
+func TestJournal_Replay(t *testing.T) {
+	journal := &journalMock{}
+	commandBus := newJournaledBus(journal, &depositHandler{})
+	commandBus.Dispatch(context.Background(), newDepositCommand(10))
+	commandBus.Dispatch(context.Background(), newDepositCommand(-1))
+	commandBus.Dispatch(context.Background(), newDepositCommand(5))
+
+	replayHandler := &depositHandler{}
+	replayBus := bus.NewCommandBus(handlers.NewCommandHandlerExecutor())
+	replayBus.Register(reflect.TypeOf(depositCommand{}), replayHandler)
+
+	report, err := Replay(context.Background(), journal, Filter{OnlySuccess: true}, replayBus)
+	if err != nil {
+		t.Fatalf("Error on replay\nError: %s", err)
+	}
+	if report.Replayed != 2 || len(report.Failed) != 0 {
+		t.Errorf("Replayed commands must be 2 without failures got %v", report)
+	}
+	if replayHandler.balance != 15 {
+		t.Errorf("Replayed balance must be 15 got %v", replayHandler.balance)
+	}
+}
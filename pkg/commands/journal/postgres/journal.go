@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/commands/journal"
+	"github.com/drprado2/go-backend-framework/pkg/storage"
+	"github.com/lib/pq"
+	"strings"
+	"time"
+)
+
+const (
+	createTableQuery = `create table if not exists command_journal (
+		sequence bigserial primary key,
+		command_id uuid not null,
+		command_publish_date timestamptz not null,
+		command_type varchar not null,
+		payload jsonb not null,
+		code_result int not null,
+		duration_ns bigint not null,
+		executed_at timestamptz not null
+	);
+	create index if not exists command_journal_publish_date_idx on command_journal (command_publish_date);
+	create or replace function command_journal_append_only() returns trigger as $$
+	begin
+		raise exception 'The command journal is append only';
+	end;
+	$$ language plpgsql;
+	drop trigger if exists command_journal_append_only on command_journal;
+	create trigger command_journal_append_only before update or delete or truncate on command_journal
+		for each statement execute procedure command_journal_append_only()`
+	insertQuery = `insert into command_journal
+		(command_id, command_publish_date, command_type, payload, code_result, duration_ns, executed_at)
+		values ($1, $2, $3, $4, $5, $6, $7)`
+	selectQuery = `select sequence, command_id, command_publish_date, command_type, payload, code_result, duration_ns, executed_at
+		from command_journal`
+)
+
+type Journal struct {
+	db storage.DatabaseInterface
+}
+
+func NewJournal(db storage.DatabaseInterface) *Journal {
+	return &Journal{
+		db: db,
+	}
+}
+
+func (j *Journal) CreateTable(ctx context.Context) error {
+	_, err := j.db.ExecContext(ctx, createTableQuery)
+	return err
+}
+
+func (j *Journal) Append(ctx context.Context, entry journal.Entry) error {
+	_, err := j.db.ExecContext(ctx, insertQuery, entry.CommandId, entry.CommandPublishDate, entry.CommandType,
+		entry.Payload, entry.CodeResult, int64(entry.Duration), entry.ExecutedAt)
+	return err
+}
+
+func (j *Journal) Read(ctx context.Context, filter journal.Filter, readFunc func(entry journal.Entry) error) error {
+	query, args := buildSelectQuery(filter)
+	rows, err := j.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry := journal.Entry{}
+		var duration int64
+		if err := rows.Scan(&entry.Sequence, &entry.CommandId, &entry.CommandPublishDate, &entry.CommandType,
+			&entry.Payload, &entry.CodeResult, &duration, &entry.ExecutedAt); err != nil {
+			return err
+		}
+		entry.Duration = time.Duration(duration)
+		if err := readFunc(entry); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func buildSelectQuery(filter journal.Filter) (string, []interface{}) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if !filter.FromPublishDate.IsZero() {
+		addCondition("command_publish_date >= $%d", filter.FromPublishDate)
+	}
+	if !filter.ToPublishDate.IsZero() {
+		addCondition("command_publish_date <= $%d", filter.ToPublishDate)
+	}
+	if filter.FromSequence > 0 {
+		addCondition("sequence >= $%d", filter.FromSequence)
+	}
+	if filter.ToSequence > 0 {
+		addCondition("sequence <= $%d", filter.ToSequence)
+	}
+	if len(filter.CommandTypes) > 0 {
+		addCondition("command_type = any($%d)", pq.Array(filter.CommandTypes))
+	}
+	if filter.OnlySuccess {
+		addCondition("code_result = $%d", commands.SuccessCodeResult)
+	}
+
+	query := selectQuery
+	if len(conditions) > 0 {
+		query += " where " + strings.Join(conditions, " and ")
+	}
+	return query + " order by sequence", args
+}
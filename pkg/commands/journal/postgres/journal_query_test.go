@@ -0,0 +1,25 @@
+package postgres
+
+import (
+	"github.com/drprado2/go-backend-framework/pkg/commands/journal"
+	"testing"
+	"time"
+)
+
+func TestJournal_BuildSelectQuery(t *testing.T) {
+	query, args := buildSelectQuery(journal.Filter{})
+	if query != selectQuery+" order by sequence" || len(args) != 0 {
+		t.Errorf("Query without filter must not have conditions got %v", query)
+	}
+
+	query, args = buildSelectQuery(journal.Filter{
+		FromPublishDate: time.Now(),
+		ToSequence:      10,
+		CommandTypes:    []string{"deposit"},
+		OnlySuccess:     true,
+	})
+	expectedQuery := selectQuery + " where command_publish_date >= $1 and sequence <= $2 and command_type = any($3) and code_result = $4 order by sequence"
+	if query != expectedQuery || len(args) != 4 {
+		t.Errorf("Invalid query expected\n%v\ngot\n%v", expectedQuery, query)
+	}
+}
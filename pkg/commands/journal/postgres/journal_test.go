@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"github.com/drprado2/go-backend-framework/pkg/commands/journal"
+	"github.com/drprado2/go-backend-framework/pkg/storage"
+	storagepostgres "github.com/drprado2/go-backend-framework/pkg/storage/postgres"
+	"github.com/drprado2/go-backend-framework/pkg/tests/testutilities"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+type journalFixture struct {
+	fullDB  storage.FullDatabaseInterface
+	journal *Journal
+}
+
+func (fixture *journalFixture) setup(t *testing.T) {
+	database := testutilities.NewTestDatabase(t)
+	var err error
+	if fixture.fullDB, err = storagepostgres.NewDatabaseFactory(database.ConnectionString).GetDB(); err != nil {
+		t.Fatal("Error in setup", err)
+	}
+
+	fixture.journal = NewJournal(fixture.fullDB)
+	if err := fixture.journal.CreateTable(context.Background()); err != nil {
+		t.Fatal("Error creating table", err)
+	}
+}
+
+func (fixture *journalFixture) teardown(t *testing.T) {
+	fixture.fullDB.Close()
+}
+
+func TestJournal_AppendAndRead(t *testing.T) {
+	fixture := journalFixture{}
+	fixture.setup(t)
+	defer fixture.teardown(t)
+
+	ctx := context.Background()
+	publishDate := time.Now().Add(-time.Hour)
+	for i, commandType := range []string{"deposit", "withdraw", "deposit"} {
+		err := fixture.journal.Append(ctx, journal.Entry{
+			CommandId:          uuid.New(),
+			CommandPublishDate: publishDate.Add(time.Duration(i) * time.Minute),
+			CommandType:        commandType,
+			Payload:            []byte(`{}`),
+			Duration:           time.Millisecond,
+			ExecutedAt:         time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("Error appending entry\nError: %s", err)
+		}
+	}
+
+	entries := make([]journal.Entry, 0)
+	err := fixture.journal.Read(ctx, journal.Filter{CommandTypes: []string{"deposit"}}, func(entry journal.Entry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("Read entries must be 2 got %v, error %v", len(entries), err)
+	}
+	if entries[0].Sequence != 1 || entries[1].Sequence != 3 || entries[0].Duration != time.Millisecond {
+		t.Errorf("Invalid entries read %v", entries)
+	}
+
+	if _, err := fixture.fullDB.ExecContext(ctx, `update command_journal set code_result = 0`); err == nil {
+		t.Errorf("Update on the journal must fail")
+	}
+	if _, err := fixture.fullDB.ExecContext(ctx, `delete from command_journal`); err == nil {
+		t.Errorf("Delete on the journal must fail")
+	}
+	if _, err := fixture.fullDB.ExecContext(ctx, `truncate command_journal`); err == nil {
+		t.Errorf("Truncate on the journal must fail")
+	}
+}
@@ -0,0 +1,163 @@
+package retry
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/commands/handlers"
+	"github.com/lib/pq"
+	"io"
+	"math"
+	"math/rand"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	AttemptsContextKey = "attempts"
+
+	serializationFailureCode = "40001"
+	deadlockDetectedCode     = "40P01"
+	connectionExceptionClass = "08"
+)
+
+type Policy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter is the fraction of the backoff that is randomly discounted, between 0 and 1
+	Jitter      float64
+	IsRetryable func(err error) bool
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:    3,
+		InitialBackoff: 50 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.5,
+		IsRetryable:    IsTransientError,
+	}
+}
+
+func (policy Policy) validate() error {
+	if policy.MaxAttempts < 1 {
+		return fmt.Errorf("The max attempts must be greater than 0")
+	}
+	if policy.InitialBackoff < 0 || policy.MaxBackoff < 0 || policy.Multiplier < 1 {
+		return fmt.Errorf("The backoffs must not be negative and the multiplier must be at least 1")
+	}
+	if policy.Jitter < 0 || policy.Jitter > 1 {
+		return fmt.Errorf("The jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+// Backoff returns the wait before the next attempt, the attempt starts at 1
+func (policy Policy) Backoff(attempt int) time.Duration {
+	backoff := float64(policy.InitialBackoff) * math.Pow(policy.Multiplier, float64(attempt-1))
+	if policy.MaxBackoff > 0 && backoff > float64(policy.MaxBackoff) {
+		backoff = float64(policy.MaxBackoff)
+	}
+	backoff -= backoff * policy.Jitter * rand.Float64()
+	return time.Duration(backoff)
+}
+
+func (policy Policy) isRetryable(err error) bool {
+	if policy.IsRetryable == nil {
+		return IsTransientError(err)
+	}
+	return policy.IsRetryable(err)
+}
+
+// IsTransientError reports serialization failures, deadlocks and broken connections
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == serializationFailureCode ||
+			pqErr.Code == deadlockDetectedCode ||
+			pqErr.Code.Class() == connectionExceptionClass
+	}
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+type RetryCommandExecutor struct {
+	executor      handlers.CommandHandlerExecutorInterface
+	defaultPolicy Policy
+	policies      map[reflect.Type]Policy
+	mutex         sync.RWMutex
+}
+
+// NewRetryCommandExecutor retries the failed executions of the executor, to run each attempt in a fresh
+// transaction the handler must be wrapped by a handlers.TransactionalCommandHandler
+func NewRetryCommandExecutor(executor handlers.CommandHandlerExecutorInterface, defaultPolicy Policy) (*RetryCommandExecutor, error) {
+	if err := defaultPolicy.validate(); err != nil {
+		return nil, err
+	}
+	return &RetryCommandExecutor{
+		executor:      executor,
+		defaultPolicy: defaultPolicy,
+		policies:      make(map[reflect.Type]Policy),
+	}, nil
+}
+
+func (executor *RetryCommandExecutor) SetPolicy(commandType reflect.Type, policy Policy) error {
+	if err := policy.validate(); err != nil {
+		return err
+	}
+	for commandType.Kind() == reflect.Ptr {
+		commandType = commandType.Elem()
+	}
+	executor.mutex.Lock()
+	defer executor.mutex.Unlock()
+	executor.policies[commandType] = policy
+	return nil
+}
+
+func (executor *RetryCommandExecutor) getPolicy(command commands.Command) Policy {
+	commandType := reflect.TypeOf(command)
+	for commandType.Kind() == reflect.Ptr {
+		commandType = commandType.Elem()
+	}
+	executor.mutex.RLock()
+	defer executor.mutex.RUnlock()
+	if policy, ok := executor.policies[commandType]; ok {
+		return policy
+	}
+	return executor.defaultPolicy
+}
+
+func (executor *RetryCommandExecutor) ExecuteCommand(ctx context.Context, command commands.Command, handler handlers.CommandHandlerInterface) commands.CommandResult {
+	policy := executor.getPolicy(command)
+
+	for attempt := 1; ; attempt++ {
+		result := executor.executor.ExecuteCommand(ctx, command, handler)
+		if result.ContextData == nil {
+			result.ContextData = make(map[string]interface{})
+		}
+		result.ContextData[AttemptsContextKey] = attempt
+
+		if result.Error == nil || attempt >= policy.MaxAttempts || !policy.isRetryable(result.Error) {
+			return result
+		}
+
+		timer := time.NewTimer(policy.Backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return result
+		}
+	}
+}
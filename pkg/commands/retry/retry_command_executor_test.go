@@ -0,0 +1,154 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/commands/handlers"
+	"github.com/drprado2/go-backend-framework/pkg/storage"
+	"github.com/lib/pq"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type retryCommand struct {
+	commands.CommandBase
+}
+
+type failingHandler struct {
+	calls     int
+	failTimes int
+	err       error
+}
+
+func (handler *failingHandler) Handle(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult {
+	handler.calls++
+	if handler.calls <= handler.failTimes {
+		return result.Fail(commands.InternalErrorCodeResult, handler.err)
+	}
+	return result
+}
+
+func fastPolicy(maxAttempts int) Policy {
+	policy := DefaultPolicy()
+	policy.MaxAttempts = maxAttempts
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return policy
+}
+
+func TestRetryCommandExecutor_RetryTransientError(t *testing.T) {
+	executor, _ := NewRetryCommandExecutor(handlers.NewCommandHandlerExecutor(), fastPolicy(3))
+	handler := &failingHandler{failTimes: 2, err: &pq.Error{Code: "40001"}}
+
+	result := executor.ExecuteCommand(context.Background(), &retryCommand{}, handler)
+
+	if !result.IsSuccess() || handler.calls != 3 {
+		t.Errorf("Result must be success after 3 calls got %v calls, result %v", handler.calls, result)
+	}
+	if result.ContextData[AttemptsContextKey] != 3 {
+		t.Errorf("Attempts must be 3 got %v", result.ContextData[AttemptsContextKey])
+	}
+}
+
+func TestRetryCommandExecutor_MaxAttempts(t *testing.T) {
+	executor, _ := NewRetryCommandExecutor(handlers.NewCommandHandlerExecutor(), fastPolicy(2))
+	handler := &failingHandler{failTimes: 5, err: &pq.Error{Code: "40P01"}}
+
+	result := executor.ExecuteCommand(context.Background(), &retryCommand{}, handler)
+
+	if result.IsSuccess() || handler.calls != 2 {
+		t.Errorf("Result must fail after 2 calls got %v calls, result %v", handler.calls, result)
+	}
+}
+
+func TestRetryCommandExecutor_NotRetryableError(t *testing.T) {
+	executor, _ := NewRetryCommandExecutor(handlers.NewCommandHandlerExecutor(), fastPolicy(3))
+	handler := &failingHandler{failTimes: 5, err: errors.New("invalid command")}
+
+	result := executor.ExecuteCommand(context.Background(), &retryCommand{}, handler)
+
+	if result.IsSuccess() || handler.calls != 1 {
+		t.Errorf("Result must fail after 1 call got %v calls, result %v", handler.calls, result)
+	}
+}
+
+func TestRetryCommandExecutor_PolicyByCommandType(t *testing.T) {
+	executor, _ := NewRetryCommandExecutor(handlers.NewCommandHandlerExecutor(), fastPolicy(1))
+	executor.SetPolicy(reflect.TypeOf(retryCommand{}), fastPolicy(4))
+	handler := &failingHandler{failTimes: 3, err: syscall.ECONNRESET}
+
+	result := executor.ExecuteCommand(context.Background(), &retryCommand{}, handler)
+
+	if !result.IsSuccess() || handler.calls != 4 {
+		t.Errorf("Result must be success after 4 calls got %v calls, result %v", handler.calls, result)
+	}
+}
+
+func TestRetryCommandExecutor_FreshTransactionByAttempt(t *testing.T) {
+	calls := make([]string, 0)
+	unitOfWork := &storage.UnitOfWorkMock{
+		BeginTranMock: func() error {
+			calls = append(calls, "begin")
+			return nil
+		},
+		CommitMock: func() error {
+			calls = append(calls, "commit")
+			if len(calls) == 2 {
+				return &pq.Error{Code: "40001"}
+			}
+			return nil
+		},
+		RollbackMock: func() error {
+			calls = append(calls, "rollback")
+			return nil
+		},
+	}
+	executor, _ := NewRetryCommandExecutor(handlers.NewCommandHandlerExecutor(), fastPolicy(3))
+	handler := handlers.NewTransactionalCommandHandler(unitOfWork, nil, &failingHandler{})
+
+	result := executor.ExecuteCommand(context.Background(), &retryCommand{}, handler)
+
+	expectedCalls := fmt.Sprint([]string{"begin", "commit", "begin", "commit"})
+	if !result.IsSuccess() || fmt.Sprint(calls) != expectedCalls {
+		t.Errorf("Calls must be %v got %v, result %v", expectedCalls, calls, result)
+	}
+}
+
+func TestPolicy_Backoff(t *testing.T) {
+	policy := Policy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+		Jitter:         0.5,
+	}
+	expectedMax := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second}
+	for i, max := range expectedMax {
+		backoff := policy.Backoff(i + 1)
+		if backoff > max || backoff < max/2 {
+			t.Errorf("Backoff of attempt %v must be between %v and %v got %v", i+1, max/2, max, backoff)
+		}
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	transients := []error{
+		&pq.Error{Code: "40001"},
+		&pq.Error{Code: "40P01"},
+		&pq.Error{Code: "08006"},
+		fmt.Errorf("Error on commit\nError: %w", &pq.Error{Code: "40001"}),
+		syscall.ECONNRESET,
+	}
+	for _, err := range transients {
+		if !IsTransientError(err) {
+			t.Errorf("Error %v must be transient", err)
+		}
+	}
+	if IsTransientError(&pq.Error{Code: "23505"}) || IsTransientError(errors.New("invalid")) {
+		t.Errorf("Unique violation and generic errors must not be transient")
+	}
+}
@@ -0,0 +1,255 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/commands/bus"
+	"github.com/drprado2/go-backend-framework/pkg/commands/retry"
+	"github.com/drprado2/go-backend-framework/pkg/commands/scheduler"
+	"github.com/drprado2/go-backend-framework/pkg/storage"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	createTableQuery = `create table if not exists scheduled_commands (
+		command_id uuid primary key,
+		command_type varchar not null,
+		payload jsonb not null,
+		due_at timestamptz not null,
+		status varchar not null,
+		attempts int not null default 0,
+		last_error varchar not null default '',
+		created_at timestamptz not null default now(),
+		lease_until timestamptz,
+		lease_token uuid,
+		updated_at timestamptz not null default now()
+	);
+	create index if not exists scheduled_commands_due_at_idx on scheduled_commands (due_at) where status = 'pending'`
+	insertQuery = `insert into scheduled_commands (command_id, command_type, payload, due_at, status) values ($1, $2, $3, $4, $5)`
+	cancelQuery = `update scheduled_commands set status = $2, updated_at = now() where command_id = $1 and status = $3`
+	selectQuery = `select command_id, command_type, payload, due_at, status, attempts, last_error from scheduled_commands where command_id = $1`
+	// claimQuery takes the due pending commands and the running ones whose lease expired, the lease token
+	// of the claim is required to write the outcome
+	claimQuery = `update scheduled_commands set status = $1, lease_until = now() + make_interval(secs => $2), lease_token = $5, updated_at = now()
+		where command_id in (
+			select command_id from scheduled_commands
+			where (status = $3 and due_at <= now()) or (status = $1 and lease_until <= now())
+			order by due_at limit $4 for update skip locked)
+		returning command_id, command_type, payload, attempts`
+	updateQuery = `update scheduled_commands set status = $2, attempts = $3, last_error = $4, due_at = $5, lease_until = null, lease_token = null, updated_at = now()
+		where command_id = $1 and lease_token = $6`
+)
+
+// ErrLeaseLost is returned by Poll when a dispatch outlived its lease, the outcome of the command is
+// written by the poll that claimed it again
+var ErrLeaseLost = errors.New("The lease of the command expired before its outcome was written")
+
+type Options struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// LeaseDuration must cover the dispatch of a whole batch, the running commands with an expired
+	// lease are claimed again
+	LeaseDuration time.Duration
+	// RetryPolicy with a nil IsRetryable retries every failure until the max attempts
+	RetryPolicy retry.Policy
+	// OnError receives the errors of the polls running in background
+	OnError func(err error)
+}
+
+func DefaultOptions() Options {
+	return Options{
+		PollInterval:  time.Second,
+		BatchSize:     50,
+		LeaseDuration: 5 * time.Minute,
+		RetryPolicy: retry.Policy{
+			MaxAttempts:    5,
+			InitialBackoff: 5 * time.Second,
+			MaxBackoff:     5 * time.Minute,
+			Multiplier:     2,
+			Jitter:         0.2,
+		},
+	}
+}
+
+// Scheduler persists the commands and dispatches them when they are due, the claimed rows stay running
+// until their outcome is written or the lease expires, so a crash can dispatch a command again, combine
+// it with the IdempotentCommandHandler when the handler is not idempotent
+type Scheduler struct {
+	db      storage.FullDatabaseInterface
+	bus     bus.CommandBusInterface
+	options Options
+}
+
+func NewScheduler(db storage.FullDatabaseInterface, commandBus bus.CommandBusInterface, options Options) (*Scheduler, error) {
+	if options.PollInterval <= 0 || options.BatchSize < 1 || options.LeaseDuration <= 0 || options.RetryPolicy.MaxAttempts < 1 {
+		return nil, fmt.Errorf("The poll interval, batch size, lease duration and retry max attempts must be greater than 0")
+	}
+	return &Scheduler{
+		db:      db,
+		bus:     commandBus,
+		options: options,
+	}, nil
+}
+
+func (s *Scheduler) CreateTable(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, createTableQuery)
+	return err
+}
+
+func (s *Scheduler) Schedule(ctx context.Context, command commands.Command, dueAt time.Time) error {
+	commandId := command.GetCommandBase().CommandId
+	if commandId == uuid.Nil {
+		return fmt.Errorf("The command id must be filled to schedule the command")
+	}
+	typeName, payload, err := bus.MarshalCommand(command)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, insertQuery, commandId, typeName, payload, dueAt, scheduler.PendingStatus)
+	return err
+}
+
+func (s *Scheduler) ScheduleIn(ctx context.Context, command commands.Command, delay time.Duration) error {
+	return s.Schedule(ctx, command, time.Now().Add(delay))
+}
+
+func (s *Scheduler) Cancel(ctx context.Context, commandId uuid.UUID) (bool, error) {
+	res, err := s.db.ExecContext(ctx, cancelQuery, commandId, scheduler.CanceledStatus, scheduler.PendingStatus)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
+
+func (s *Scheduler) Get(ctx context.Context, commandId uuid.UUID) (*scheduler.ScheduledCommand, error) {
+	command := scheduler.ScheduledCommand{}
+	err := s.db.QueryRowContext(ctx, selectQuery, commandId).Scan(
+		&command.CommandId, &command.CommandType, &command.Payload, &command.DueAt, &command.Status, &command.Attempts, &command.LastError)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &command, nil
+}
+
+// Run polls the due commands until the ctx is done
+func (s *Scheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.options.PollInterval)
+	defer ticker.Stop()
+	for {
+		for {
+			dispatched, err := s.Poll(ctx)
+			if err != nil && s.options.OnError != nil {
+				s.options.OnError(err)
+			}
+			if err != nil || dispatched < s.options.BatchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+type claimedCommand struct {
+	commandId   uuid.UUID
+	commandType string
+	payload     []byte
+	attempts    int
+	leaseToken  uuid.UUID
+}
+
+// Poll claims one batch of due commands, dispatches them and returns how many were claimed, the claim is
+// committed before the dispatch and the outcome of each command is written on its own
+func (s *Scheduler) Poll(ctx context.Context) (int, error) {
+	claimed, err := s.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	errs := make([]error, 0)
+	for _, command := range claimed {
+		if err := s.dispatch(ctx, command); err != nil {
+			errs = append(errs, fmt.Errorf("Error writing the outcome of the command %s\nError: %w", command.commandId, err))
+		}
+	}
+	return len(claimed), errors.Join(errs...)
+}
+
+func (s *Scheduler) claim(ctx context.Context) ([]claimedCommand, error) {
+	leaseToken := uuid.New()
+	rows, err := s.db.QueryContext(ctx, claimQuery, scheduler.RunningStatus, s.options.LeaseDuration.Seconds(), scheduler.PendingStatus, s.options.BatchSize, leaseToken)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	claimed := make([]claimedCommand, 0, s.options.BatchSize)
+	for rows.Next() {
+		command := claimedCommand{leaseToken: leaseToken}
+		if err := rows.Scan(&command.commandId, &command.commandType, &command.payload, &command.attempts); err != nil {
+			return nil, err
+		}
+		claimed = append(claimed, command)
+	}
+	return claimed, rows.Err()
+}
+
+func (s *Scheduler) dispatch(ctx context.Context, claimed claimedCommand) error {
+	attempts := claimed.attempts + 1
+	err := s.dispatchCommand(ctx, claimed)
+	if err == nil {
+		return s.writeOutcome(ctx, claimed, scheduler.DoneStatus, attempts, "", time.Now())
+	}
+
+	policy := s.options.RetryPolicy
+	status := scheduler.PendingStatus
+	if attempts >= policy.MaxAttempts || (policy.IsRetryable != nil && !policy.IsRetryable(err)) {
+		status = scheduler.DeadLetterStatus
+	}
+	return s.writeOutcome(ctx, claimed, status, attempts, err.Error(), time.Now().Add(policy.Backoff(attempts)))
+}
+
+// writeOutcome returns ErrLeaseLost when the lease expired and the command was claimed again
+func (s *Scheduler) writeOutcome(ctx context.Context, claimed claimedCommand, status string, attempts int, lastError string, dueAt time.Time) error {
+	res, err := s.db.ExecContext(ctx, updateQuery, claimed.commandId, status, attempts, lastError, dueAt, claimed.leaseToken)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (s *Scheduler) dispatchCommand(ctx context.Context, claimed claimedCommand) error {
+	command, err := bus.UnmarshalCommand(s.bus, claimed.commandType, claimed.payload)
+	if err != nil {
+		return err
+	}
+	result, err := s.bus.Dispatch(ctx, command)
+	if err != nil {
+		return err
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	if !result.IsSuccess() {
+		return fmt.Errorf("The command finished with the code result %v", result.CodeResult)
+	}
+	return nil
+}
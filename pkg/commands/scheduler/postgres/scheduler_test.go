@@ -0,0 +1,240 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/commands/bus"
+	"github.com/drprado2/go-backend-framework/pkg/commands/handlers"
+	"github.com/drprado2/go-backend-framework/pkg/commands/scheduler"
+	"github.com/drprado2/go-backend-framework/pkg/storage"
+	storagepostgres "github.com/drprado2/go-backend-framework/pkg/storage/postgres"
+	"github.com/drprado2/go-backend-framework/pkg/tests/testutilities"
+	"github.com/google/uuid"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type sendEmailCommand struct {
+	commands.CommandBase
+	To string
+}
+
+type sendEmailHandler struct {
+	sentTo []string
+	err    error
+	// hook runs before the handler and fails the command when it returns an error
+	hook func() error
+}
+
+func (handler *sendEmailHandler) Handle(ctx context.Context, command commands.Command, result commands.CommandResult) commands.CommandResult {
+	if handler.hook != nil {
+		if err := handler.hook(); err != nil {
+			return result.Fail(commands.InternalErrorCodeResult, err)
+		}
+	}
+	if handler.err != nil {
+		return result.Fail(commands.InternalErrorCodeResult, handler.err)
+	}
+	handler.sentTo = append(handler.sentTo, command.(*sendEmailCommand).To)
+	return result
+}
+
+// failingOutcomeDatabase fails the outcome update of one command
+type failingOutcomeDatabase struct {
+	storage.FullDatabaseInterface
+	commandId uuid.UUID
+}
+
+func (db *failingOutcomeDatabase) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if query == updateQuery && args[0] == db.commandId {
+		return nil, errors.New("connection lost")
+	}
+	return db.FullDatabaseInterface.ExecContext(ctx, query, args...)
+}
+
+type schedulerFixture struct {
+	fullDB    storage.FullDatabaseInterface
+	handler   *sendEmailHandler
+	scheduler *Scheduler
+}
+
+func (fixture *schedulerFixture) setup(t *testing.T) {
+	database := testutilities.NewTestDatabase(t)
+	var err error
+	if fixture.fullDB, err = storagepostgres.NewDatabaseFactory(database.ConnectionString).GetDB(); err != nil {
+		t.Fatal("Error in setup", err)
+	}
+
+	fixture.handler = &sendEmailHandler{}
+	fixture.scheduler = fixture.newScheduler(fixture.fullDB)
+	if err := fixture.scheduler.CreateTable(context.Background()); err != nil {
+		t.Fatal("Error creating table", err)
+	}
+}
+
+func (fixture *schedulerFixture) newScheduler(db storage.FullDatabaseInterface) *Scheduler {
+	commandBus := bus.NewCommandBus(handlers.NewCommandHandlerExecutor())
+	commandBus.Register(reflect.TypeOf(sendEmailCommand{}), fixture.handler)
+
+	options := DefaultOptions()
+	options.RetryPolicy.MaxAttempts = 2
+	options.RetryPolicy.InitialBackoff = 0
+	commandScheduler, _ := NewScheduler(db, commandBus, options)
+	return commandScheduler
+}
+
+func (fixture *schedulerFixture) teardown(t *testing.T) {
+	fixture.fullDB.Close()
+}
+
+func newSendEmailCommand(to string) *sendEmailCommand {
+	return &sendEmailCommand{
+		CommandBase: commands.CommandBase{CommandId: uuid.New(), CommandPublishDate: time.Now()},
+		To:          to,
+	}
+}
+
+func TestScheduler_PollDueCommands(t *testing.T) {
+	fixture := schedulerFixture{}
+	fixture.setup(t)
+	defer fixture.teardown(t)
+
+	ctx := context.Background()
+	due := newSendEmailCommand("due@mail.com")
+	future := newSendEmailCommand("future@mail.com")
+	fixture.scheduler.Schedule(ctx, due, time.Now().Add(-time.Second))
+	fixture.scheduler.ScheduleIn(ctx, future, time.Hour)
+
+	dispatched, err := fixture.scheduler.Poll(ctx)
+	if err != nil || dispatched != 1 {
+		t.Fatalf("Dispatched commands must be 1 got %v, error %v", dispatched, err)
+	}
+	if len(fixture.handler.sentTo) != 1 || fixture.handler.sentTo[0] != "due@mail.com" {
+		t.Errorf("Only the due command must be handled got %v", fixture.handler.sentTo)
+	}
+	if stored, _ := fixture.scheduler.Get(ctx, due.CommandId); stored.Status != scheduler.DoneStatus {
+		t.Errorf("Due command status must be done got %v", stored.Status)
+	}
+	if stored, _ := fixture.scheduler.Get(ctx, future.CommandId); stored.Status != scheduler.PendingStatus {
+		t.Errorf("Future command status must be pending got %v", stored.Status)
+	}
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	fixture := schedulerFixture{}
+	fixture.setup(t)
+	defer fixture.teardown(t)
+
+	ctx := context.Background()
+	command := newSendEmailCommand("canceled@mail.com")
+	fixture.scheduler.Schedule(ctx, command, time.Now())
+
+	if canceled, err := fixture.scheduler.Cancel(ctx, command.CommandId); !canceled || err != nil {
+		t.Fatalf("Command must be canceled got %v, error %v", canceled, err)
+	}
+	if dispatched, _ := fixture.scheduler.Poll(ctx); dispatched != 0 {
+		t.Errorf("Canceled command must not be dispatched")
+	}
+	if canceled, _ := fixture.scheduler.Cancel(ctx, command.CommandId); canceled {
+		t.Errorf("Command already canceled must not be canceled again")
+	}
+}
+
+func TestScheduler_DeadLetter(t *testing.T) {
+	fixture := schedulerFixture{}
+	fixture.setup(t)
+	defer fixture.teardown(t)
+
+	ctx := context.Background()
+	fixture.handler.err = errors.New("smtp unavailable")
+	command := newSendEmailCommand("fail@mail.com")
+	fixture.scheduler.Schedule(ctx, command, time.Now())
+
+	fixture.scheduler.Poll(ctx)
+	if stored, _ := fixture.scheduler.Get(ctx, command.CommandId); stored.Status != scheduler.PendingStatus || stored.Attempts != 1 {
+		t.Fatalf("Command must be pending after the first attempt got %v", stored)
+	}
+	fixture.scheduler.Poll(ctx)
+	stored, _ := fixture.scheduler.Get(ctx, command.CommandId)
+	if stored.Status != scheduler.DeadLetterStatus || stored.Attempts != 2 || stored.LastError != "smtp unavailable" {
+		t.Errorf("Command must be dead letter after the second attempt got %v", stored)
+	}
+}
+
+func TestScheduler_OutcomeErrorKeepsDispatchedCommands(t *testing.T) {
+	fixture := schedulerFixture{}
+	fixture.setup(t)
+	defer fixture.teardown(t)
+
+	ctx := context.Background()
+	first := newSendEmailCommand("first@mail.com")
+	second := newSendEmailCommand("second@mail.com")
+	fixture.scheduler.Schedule(ctx, first, time.Now().Add(-2*time.Second))
+	fixture.scheduler.Schedule(ctx, second, time.Now().Add(-time.Second))
+
+	failingScheduler := fixture.newScheduler(&failingOutcomeDatabase{FullDatabaseInterface: fixture.fullDB, commandId: second.CommandId})
+	dispatched, err := failingScheduler.Poll(ctx)
+	if dispatched != 2 || err == nil {
+		t.Fatalf("Poll must claim 2 commands and fail the outcome of the second got %v, error %v", dispatched, err)
+	}
+	if stored, _ := fixture.scheduler.Get(ctx, first.CommandId); stored.Status != scheduler.DoneStatus {
+		t.Errorf("First command status must be done got %v", stored.Status)
+	}
+	if stored, _ := fixture.scheduler.Get(ctx, second.CommandId); stored.Status != scheduler.RunningStatus {
+		t.Errorf("Second command status must stay running until the lease expires got %v", stored.Status)
+	}
+	if dispatched, _ := fixture.scheduler.Poll(ctx); dispatched != 0 {
+		t.Errorf("Commands must not be dispatched again before the lease expires got %v", dispatched)
+	}
+
+	if _, err := fixture.fullDB.ExecContext(ctx, "update scheduled_commands set lease_until = now() - interval '1 second' where command_id = $1", second.CommandId); err != nil {
+		t.Fatal("Error expiring the lease", err)
+	}
+	if dispatched, err := fixture.scheduler.Poll(ctx); dispatched != 1 || err != nil {
+		t.Fatalf("Command with an expired lease must be dispatched again got %v, error %v", dispatched, err)
+	}
+	if stored, _ := fixture.scheduler.Get(ctx, second.CommandId); stored.Status != scheduler.DoneStatus {
+		t.Errorf("Second command status must be done got %v", stored.Status)
+	}
+	expected := []string{"first@mail.com", "second@mail.com", "second@mail.com"}
+	if !reflect.DeepEqual(fixture.handler.sentTo, expected) {
+		t.Errorf("Handled commands must be %v got %v", expected, fixture.handler.sentTo)
+	}
+}
+
+func TestScheduler_ExpiredLeaseClaimedAgain(t *testing.T) {
+	fixture := schedulerFixture{}
+	fixture.setup(t)
+	defer fixture.teardown(t)
+
+	ctx := context.Background()
+	command := newSendEmailCommand("slow@mail.com")
+	fixture.scheduler.Schedule(ctx, command, time.Now())
+
+	// the slow dispatch outlives its lease and another scheduler claims and completes the command
+	fixture.handler.hook = func() error {
+		fixture.handler.hook = nil
+		if _, err := fixture.fullDB.ExecContext(ctx, "update scheduled_commands set lease_until = now() - interval '1 second' where command_id = $1", command.CommandId); err != nil {
+			t.Fatal("Error expiring the lease", err)
+		}
+		if dispatched, err := fixture.scheduler.Poll(ctx); dispatched != 1 || err != nil {
+			t.Errorf("Command with an expired lease must be claimed again got %v, error %v", dispatched, err)
+		}
+		return errors.New("smtp timeout")
+	}
+	slowScheduler := fixture.newScheduler(fixture.fullDB)
+	if dispatched, err := slowScheduler.Poll(ctx); dispatched != 1 || !errors.Is(err, ErrLeaseLost) {
+		t.Fatalf("Outcome of the expired lease must fail with ErrLeaseLost got %v, error %v", dispatched, err)
+	}
+
+	stored, _ := fixture.scheduler.Get(ctx, command.CommandId)
+	if stored.Status != scheduler.DoneStatus || stored.Attempts != 1 || stored.LastError != "" {
+		t.Errorf("Outcome of the second claim must be kept got %v", stored)
+	}
+	if len(fixture.handler.sentTo) != 1 {
+		t.Errorf("Command must be handled once by the second claim got %v", fixture.handler.sentTo)
+	}
+}
@@ -0,0 +1,34 @@
+package scheduler
+
+import (
+	"context"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	PendingStatus    = "pending"
+	RunningStatus    = "running"
+	DoneStatus       = "done"
+	DeadLetterStatus = "dead_letter"
+	CanceledStatus   = "canceled"
+)
+
+type SchedulerInterface interface {
+	Schedule(ctx context.Context, command commands.Command, dueAt time.Time) error
+	ScheduleIn(ctx context.Context, command commands.Command, delay time.Duration) error
+	// Cancel returns false when the command is not pending anymore
+	Cancel(ctx context.Context, commandId uuid.UUID) (bool, error)
+	Get(ctx context.Context, commandId uuid.UUID) (*ScheduledCommand, error)
+}
+
+type ScheduledCommand struct {
+	CommandId   uuid.UUID
+	CommandType string
+	Payload     []byte
+	DueAt       time.Time
+	Status      string
+	Attempts    int
+	LastError   string
+}
@@ -0,0 +1,75 @@
+package configs
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const (
+	DatabaseSection   = "Database"
+	HttpServerSection = "HttpServer"
+	MigrationsSection = "Migrations"
+)
+
+type DatabaseOptions struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	Name     string
+}
+
+// ConnectionString is the postgres connection string of the options, without dbname when Name is empty
+func (options *DatabaseOptions) ConnectionString() string {
+	connString := fmt.Sprintf("host=%s port=%d user=%s password=%s", options.Host, options.Port, options.User, options.Password)
+	if options.Name != "" {
+		connString += " dbname=" + options.Name
+	}
+	return connString + " sslmode=disable"
+}
+
+type HttpServerOptions struct {
+	Port string
+}
+
+type MigrationsOptions struct {
+	Path string
+}
+
+// Bind fills the target, a pointer to a struct, with the Configuration fields named section plus the
+// target field name, so the section Database fills Host with DatabaseHost. Zero values in the
+// configuration keep the target value as default
+func Bind(config *Configuration, section string, target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	if target == nil || targetValue.Kind() != reflect.Ptr || targetValue.IsNil() || targetValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("The options must be a not null pointer to a struct got %v", reflect.TypeOf(target))
+	}
+	if config == nil {
+		return fmt.Errorf("The configuration must not be null")
+	}
+
+	options := targetValue.Elem()
+	configValue := reflect.ValueOf(config).Elem()
+	bound := 0
+	for i := 0; i < options.NumField(); i++ {
+		field := options.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		configField := configValue.FieldByName(section + field.Name)
+		if !configField.IsValid() {
+			continue
+		}
+		if configField.Kind() != field.Type.Kind() || !configField.Type().ConvertibleTo(field.Type) {
+			return fmt.Errorf("The configuration %s%s (%v) can`t be bound to %v", section, field.Name, configField.Type(), field.Type)
+		}
+		bound++
+		if !configField.IsZero() {
+			options.Field(i).Set(configField.Convert(field.Type))
+		}
+	}
+	if bound == 0 {
+		return fmt.Errorf("The configuration section %s has no field of %v", section, options.Type())
+	}
+	return nil
+}
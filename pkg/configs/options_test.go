@@ -0,0 +1,52 @@
+package configs
+
+import (
+	"testing"
+)
+
+func TestBind(t *testing.T) {
+	config := &Configuration{
+		DatabaseHost:   "db",
+		DatabasePort:   5432,
+		DatabaseUser:   "postgres",
+		HttpServerPort: "9000",
+	}
+
+	options := &DatabaseOptions{Password: "default", Name: "default-db"}
+	if err := Bind(config, DatabaseSection, options); err != nil {
+		t.Fatalf("Error binding database options\nError: %s", err)
+	}
+	expected := DatabaseOptions{Host: "db", Port: 5432, User: "postgres", Password: "default", Name: "default-db"}
+	if *options != expected {
+		t.Errorf("Options must be %v got %v", expected, *options)
+	}
+
+	httpOptions := &HttpServerOptions{}
+	if err := Bind(config, HttpServerSection, httpOptions); err != nil || httpOptions.Port != "9000" {
+		t.Errorf("Http port must be 9000 got %v %v", httpOptions.Port, err)
+	}
+}
+
+func TestBind_Invalid(t *testing.T) {
+	config := &Configuration{}
+	if err := Bind(config, DatabaseSection, DatabaseOptions{}); err == nil {
+		t.Errorf("Bind without pointer must fail")
+	}
+	if err := Bind(config, "Cache", &DatabaseOptions{}); err == nil {
+		t.Errorf("Bind of a section without fields must fail")
+	}
+	if err := Bind(config, DatabaseSection, &struct{ Port string }{}); err == nil {
+		t.Errorf("Bind of a not convertible field must fail")
+	}
+}
+
+func TestDatabaseOptions_ConnectionString(t *testing.T) {
+	options := &DatabaseOptions{Host: "db", Port: 5432, User: "postgres", Password: "secret"}
+	if options.ConnectionString() != "host=db port=5432 user=postgres password=secret sslmode=disable" {
+		t.Errorf("Connection string without database got %s", options.ConnectionString())
+	}
+	options.Name = "orders"
+	if options.ConnectionString() != "host=db port=5432 user=postgres password=secret dbname=orders sslmode=disable" {
+		t.Errorf("Connection string with database got %s", options.ConnectionString())
+	}
+}
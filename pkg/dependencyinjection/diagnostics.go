@@ -0,0 +1,198 @@
+package dependencyinjection
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/graphstructure"
+	"io"
+	"sort"
+	"strings"
+)
+
+var lifestyleNames = map[int]string{
+	TransientLifestyle: "transient",
+	ScopedLifestyle:    "scoped",
+	SingletonLifestyle: "singleton",
+}
+
+var lifestyleColors = map[string]string{
+	"transient": "black",
+	"scoped":    "darkgreen",
+	"singleton": "blue",
+}
+
+func LifestyleName(lifestyle int) string {
+	if name, ok := lifestyleNames[lifestyle]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// DiagramService is one service key of the diagram, a key registered more than once has one lifestyle
+// by registration and a missing key has none
+type DiagramService struct {
+	Key          string   `json:"key"`
+	Lifestyles   []string `json:"lifestyles"`
+	Hosted       bool     `json:"hosted,omitempty"`
+	Missing      bool     `json:"missing,omitempty"`
+	Dependencies []string `json:"dependencies"`
+}
+
+// DependencyDiagram describes the registrations of a builder and the services they depend on
+type DependencyDiagram struct {
+	graph    *graphstructure.Graph
+	services map[string]*DiagramService
+}
+
+func (builder *ServiceBuilder) Diagram() (*DependencyDiagram, error) {
+	graph, err := newDependencyGraph(builder.descriptors)
+	if err != nil {
+		return nil, fmt.Errorf("Error building the service dependency graph\nError: %w", err)
+	}
+	hosted := make(map[string]bool, len(builder.hostedServices))
+	for _, key := range builder.hostedServices {
+		hosted[key] = true
+	}
+
+	services := make(map[string]*DiagramService)
+	for _, vertex := range graph.GetVertices() {
+		service := &DiagramService{
+			Key:          vertex.ID,
+			Lifestyles:   make([]string, 0),
+			Hosted:       hosted[vertex.ID],
+			Dependencies: make([]string, 0),
+		}
+		registrations, _ := vertex.GenericData.([]*serviceDescriptor)
+		for _, registration := range registrations {
+			service.Lifestyles = append(service.Lifestyles, LifestyleName(registration.lifestyle))
+		}
+		service.Missing = len(registrations) == 0
+		services[vertex.ID] = service
+	}
+	for _, edge := range graph.GetEdges() {
+		services[edge.Tail.ID].Dependencies = append(services[edge.Tail.ID].Dependencies, edge.Head.ID)
+	}
+	for _, service := range services {
+		sort.Strings(service.Dependencies)
+	}
+	return &DependencyDiagram{graph: graph, services: services}, nil
+}
+
+// Graph is the dependency graph, each vertex ID is a service key and each edge goes from a service
+// to one of its dependencies
+func (diagram *DependencyDiagram) Graph() *graphstructure.Graph {
+	return diagram.graph
+}
+
+// Services returns the services sorted by key
+func (diagram *DependencyDiagram) Services() []DiagramService {
+	services := make([]DiagramService, 0, len(diagram.services))
+	for _, service := range diagram.services {
+		services = append(services, *service)
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Key < services[j].Key
+	})
+	return services
+}
+
+func (diagram *DependencyDiagram) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(diagram.Services())
+}
+
+// quoteDOT keeps the \n escapes of the labels as DOT line breaks
+func quoteDOT(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
+// WriteDOT renders the diagram in the Graphviz DOT language, the color shows the lifestyle, missing
+// services are red and dashed and hosted services have a double border
+func (diagram *DependencyDiagram) WriteDOT(w io.Writer) error {
+	builder := &strings.Builder{}
+	builder.WriteString("digraph services {\n\trankdir=LR;\n\tnode [shape=box];\n")
+	services := diagram.Services()
+	for _, service := range services {
+		attributes := make([]string, 0, 4)
+		if service.Missing {
+			attributes = append(attributes, `label=`+quoteDOT(service.Key+`\nmissing`), "color=red", "style=dashed")
+		} else {
+			attributes = append(attributes, `label=`+quoteDOT(service.Key+`\n`+strings.Join(service.Lifestyles, ", ")),
+				"color="+lifestyleColors[service.Lifestyles[len(service.Lifestyles)-1]])
+		}
+		if service.Hosted {
+			attributes = append(attributes, "peripheries=2")
+		}
+		fmt.Fprintf(builder, "\t%s [%s];\n", quoteDOT(service.Key), strings.Join(attributes, ", "))
+	}
+	for _, service := range services {
+		for _, dependency := range service.Dependencies {
+			fmt.Fprintf(builder, "\t%s -> %s;\n", quoteDOT(service.Key), quoteDOT(dependency))
+		}
+	}
+	builder.WriteString("}\n")
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// Unresolvable returns the registered services that depend directly or through other services on a
+// missing registration
+func (diagram *DependencyDiagram) Unresolvable() []string {
+	resolvable := make(map[string]bool, len(diagram.services))
+	var isResolvable func(key string) bool
+	isResolvable = func(key string) bool {
+		if result, ok := resolvable[key]; ok {
+			return result
+		}
+		// a cycle is reported by BuildServiceProvider, here it only must not loop forever
+		resolvable[key] = true
+		result := !diagram.services[key].Missing
+		for _, dependency := range diagram.services[key].Dependencies {
+			result = isResolvable(dependency) && result
+		}
+		resolvable[key] = result
+		return result
+	}
+
+	unresolvable := make([]string, 0)
+	for _, service := range diagram.Services() {
+		if !service.Missing && !isResolvable(service.Key) {
+			unresolvable = append(unresolvable, service.Key)
+		}
+	}
+	return unresolvable
+}
+
+// Unused returns the registered services that are neither a root, a hosted service nor a dependency of
+// them, the roots are the service keys resolved directly by the application
+func (diagram *DependencyDiagram) Unused(roots ...string) []string {
+	used := make(map[string]bool, len(diagram.services))
+	var use func(key string)
+	use = func(key string) {
+		service, ok := diagram.services[key]
+		if !ok || used[key] {
+			return
+		}
+		used[key] = true
+		for _, dependency := range service.Dependencies {
+			use(dependency)
+		}
+	}
+	for _, root := range roots {
+		use(root)
+	}
+	for _, service := range diagram.services {
+		if service.Hosted {
+			use(service.Key)
+		}
+	}
+
+	unused := make([]string, 0)
+	for _, service := range diagram.Services() {
+		if !service.Missing && !used[service.Key] {
+			unused = append(unused, service.Key)
+		}
+	}
+	return unused
+}
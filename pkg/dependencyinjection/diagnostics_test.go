@@ -0,0 +1,111 @@
+package dependencyinjection
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type diagnosticsTestFixture struct {
+	builder        *ServiceBuilder
+	repositoryKey  string
+	notificatorKey string
+	serviceKey     string
+}
+
+func (f *diagnosticsTestFixture) setup() {
+	f.builder = NewServiceBuilder()
+	f.builder.AddSingletonConstructor(newRepository, nil)
+	f.builder.AddScopedConstructor(newNotificator, nil)
+	f.builder.AddTransientConstructor(newUserService, nil)
+	f.repositoryKey = TypeServiceKey(reflect.TypeOf((*repositoryInterface)(nil)).Elem())
+	f.notificatorKey = TypeServiceKey(reflect.TypeOf(&notificator{}))
+	f.serviceKey = TypeServiceKey(reflect.TypeOf(&userService{}))
+}
+
+func TestDependencyDiagram_WriteJSON(t *testing.T) {
+	f := diagnosticsTestFixture{}
+	f.setup()
+	f.builder.AddSingleton("cache", nil, nil)
+	diagram, _ := f.builder.Diagram()
+
+	buffer := &bytes.Buffer{}
+	if err := diagram.WriteJSON(buffer); err != nil {
+		t.Fatalf("Error writing json\nError: %s", err)
+	}
+	services := make([]DiagramService, 0)
+	json.Unmarshal(buffer.Bytes(), &services)
+
+	if len(services) != 4 || !sort.SliceIsSorted(services, func(i, j int) bool { return services[i].Key < services[j].Key }) {
+		t.Fatalf("Services must be sorted by key got %v", services)
+	}
+	for _, service := range services {
+		if service.Key == f.serviceKey {
+			if strings.Join(service.Dependencies, ",") != strings.Join([]string{f.notificatorKey, f.repositoryKey}, ",") ||
+				service.Lifestyles[0] != "transient" {
+				t.Errorf("User service must be transient and depend on notificator and repository got %v", service)
+			}
+		}
+	}
+}
+
+func TestDependencyDiagram_WriteDOT(t *testing.T) {
+	f := diagnosticsTestFixture{}
+	f.setup()
+	diagram, _ := f.builder.Diagram()
+
+	buffer := &bytes.Buffer{}
+	if err := diagram.WriteDOT(buffer); err != nil {
+		t.Fatalf("Error writing dot\nError: %s", err)
+	}
+	dot := buffer.String()
+	expected := []string{
+		"digraph services {",
+		`"` + f.notificatorKey + `" [label="` + f.notificatorKey + `\nscoped", color=darkgreen];`,
+		`"` + f.serviceKey + `" -> "` + f.repositoryKey + `";`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(dot, line) {
+			t.Errorf("DOT must contain %s got\n%s", line, dot)
+		}
+	}
+}
+
+func TestDependencyDiagram_Unresolvable(t *testing.T) {
+	f := diagnosticsTestFixture{}
+	f.setup()
+	f.builder.descriptors = descriptorsByKey{}
+	f.builder.AddScopedConstructor(newNotificator, nil)
+	f.builder.AddTransientConstructor(newUserService, nil)
+	f.builder.AddTransientConstructor(func(service *userService) *cycleA { return &cycleA{} }, nil)
+	diagram, _ := f.builder.Diagram()
+
+	unresolvable := diagram.Unresolvable()
+	if len(unresolvable) != 2 || unresolvable[1] != f.serviceKey {
+		t.Errorf("Unresolvable must be the services depending on the repository got %v", unresolvable)
+	}
+	for _, service := range diagram.Services() {
+		if service.Key == f.repositoryKey && !service.Missing {
+			t.Errorf("Repository must be missing got %v", service)
+		}
+	}
+}
+
+func TestDependencyDiagram_Unused(t *testing.T) {
+	f := diagnosticsTestFixture{}
+	f.setup()
+	f.builder.AddSingleton("cache", nil, nil)
+	f.builder.AddHostedService(func(repository repositoryInterface) *databaseHosted { return nil }, nil)
+	diagram, _ := f.builder.Diagram()
+
+	unused := diagram.Unused(f.serviceKey)
+	if len(unused) != 1 || unused[0] != "cache" {
+		t.Errorf("Unused must be [cache] got %v", unused)
+	}
+	if unused := diagram.Unused(); len(unused) != 3 {
+		t.Errorf("Without roots only the hosted service and the repository are used got %v", unused)
+	}
+}
@@ -0,0 +1,114 @@
+package dependencyinjection
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const DefaultShutdownTimeout = 30 * time.Second
+
+// Host starts the hosted services of a provider in dependency order and stops them in reverse order
+type Host struct {
+	provider        ServiceProviderInterface
+	shutdownTimeout time.Duration
+	mutex           sync.Mutex
+	started         []HostedService
+}
+
+func NewHost(provider ServiceProviderInterface, shutdownTimeout time.Duration) *Host {
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = DefaultShutdownTimeout
+	}
+	return &Host{
+		provider:        provider,
+		shutdownTimeout: shutdownTimeout,
+		started:         make([]HostedService, 0),
+	}
+}
+
+// Start starts every hosted service, when one fails the already started ones are stopped
+func (host *Host) Start(ctx context.Context) error {
+	services, err := host.provider.GetHostedServices()
+	if err != nil {
+		return err
+	}
+
+	host.mutex.Lock()
+	defer host.mutex.Unlock()
+	for _, service := range services {
+		if err := service.Start(ctx); err != nil {
+			startErr := fmt.Errorf("Error starting the hosted service %T\nError: %w", service, err)
+			if stopErr := host.stop(ctx); stopErr != nil {
+				return fmt.Errorf("%s\n%s", startErr, stopErr)
+			}
+			return startErr
+		}
+		host.started = append(host.started, service)
+	}
+	return nil
+}
+
+// Stop stops the started hosted services in reverse order, a service that doesn`t stop before the ctx
+// is done is abandoned
+func (host *Host) Stop(ctx context.Context) error {
+	host.mutex.Lock()
+	defer host.mutex.Unlock()
+	return host.stop(ctx)
+}
+
+func (host *Host) stop(ctx context.Context) error {
+	errors := make([]string, 0)
+	for i := len(host.started) - 1; i >= 0; i-- {
+		if err := stopHostedService(ctx, host.started[i]); err != nil {
+			errors = append(errors, fmt.Sprintf("%T: %s", host.started[i], err))
+		}
+	}
+	host.started = host.started[:0]
+	if len(errors) > 0 {
+		return fmt.Errorf("Error stopping the hosted services\nError: %s", strings.Join(errors, "\n"))
+	}
+	return nil
+}
+
+func stopHostedService(ctx context.Context, service HostedService) error {
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- service.Stop(ctx)
+	}()
+	select {
+	case err := <-stopped:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Run starts the hosted services and blocks until SIGINT, SIGTERM or the ctx is done, then stops them
+// within the shutdown timeout and disposes the provider
+func (host *Host) Run(ctx context.Context) error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	if err := host.Start(ctx); err != nil {
+		host.provider.Dispose()
+		return err
+	}
+
+	select {
+	case <-signals:
+	case <-ctx.Done():
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), host.shutdownTimeout)
+	defer cancel()
+	err := host.Stop(stopCtx)
+	host.provider.Dispose()
+	return err
+}
@@ -0,0 +1,185 @@
+package dependencyinjection
+
+import (
+	"context"
+	"errors"
+	"github.com/drprado2/go-backend-framework/pkg/graphstructure"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type lifecycleLog struct {
+	mutex sync.Mutex
+	calls []string
+}
+
+func (l *lifecycleLog) add(call string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.calls = append(l.calls, call)
+}
+
+func (l *lifecycleLog) joined() string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return strings.Join(l.calls, ",")
+}
+
+type hostedMock struct {
+	name     string
+	log      *lifecycleLog
+	startErr error
+	onStart  func()
+	stopWait time.Duration
+}
+
+func (mock *hostedMock) Start(ctx context.Context) error {
+	mock.log.add("start " + mock.name)
+	if mock.onStart != nil {
+		mock.onStart()
+	}
+	return mock.startErr
+}
+
+func (mock *hostedMock) Stop(ctx context.Context) error {
+	time.Sleep(mock.stopWait)
+	mock.log.add("stop " + mock.name)
+	return nil
+}
+
+type databaseHosted struct{ *hostedMock }
+type cacheHosted struct{ *hostedMock }
+type httpHosted struct{ *hostedMock }
+
+type hostTestFixture struct {
+	log     *lifecycleLog
+	builder *ServiceBuilder
+}
+
+func (f *hostTestFixture) setup(t *testing.T, database *hostedMock, cache *hostedMock, http *hostedMock) {
+	f.log = &lifecycleLog{}
+	for _, mock := range []*hostedMock{database, cache, http} {
+		mock.log = f.log
+	}
+	f.builder = NewServiceBuilder()
+	// registered in reverse dependency order to prove the host sorts them
+	if err := f.builder.AddHostedService(func(db *databaseHosted, cache *cacheHosted) *httpHosted {
+		return &httpHosted{http}
+	}, nil); err != nil {
+		t.Fatalf("Error registering http\nError: %s", err)
+	}
+	f.builder.AddHostedService(func(db *databaseHosted) *cacheHosted { return &cacheHosted{cache} }, nil)
+	f.builder.AddHostedService(func() *databaseHosted { return &databaseHosted{database} }, nil)
+}
+
+func (f *hostTestFixture) newHost(t *testing.T, shutdownTimeout time.Duration) *Host {
+	provider, err := f.builder.BuildServiceProvider()
+	if err != nil {
+		t.Fatalf("Error building provider\nError: %s", err)
+	}
+	return NewHost(provider, shutdownTimeout)
+}
+
+func TestHost_StartAndStopInDependencyOrder(t *testing.T) {
+	f := hostTestFixture{}
+	f.setup(t, &hostedMock{name: "db"}, &hostedMock{name: "cache"}, &hostedMock{name: "http"})
+	host := f.newHost(t, time.Second)
+
+	if err := host.Start(context.Background()); err != nil {
+		t.Fatalf("Error starting host\nError: %s", err)
+	}
+	if err := host.Stop(context.Background()); err != nil {
+		t.Fatalf("Error stopping host\nError: %s", err)
+	}
+
+	expected := "start db,start cache,start http,stop http,stop cache,stop db"
+	if f.log.joined() != expected {
+		t.Errorf("Calls must be %s got %s", expected, f.log.joined())
+	}
+}
+
+func TestHost_StartFailStopsStartedServices(t *testing.T) {
+	f := hostTestFixture{}
+	f.setup(t, &hostedMock{name: "db"}, &hostedMock{name: "cache", startErr: errors.New("cache unavailable")}, &hostedMock{name: "http"})
+	host := f.newHost(t, time.Second)
+
+	err := host.Start(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "cache unavailable") {
+		t.Errorf("Error must contain the start error got %v", err)
+	}
+	expected := "start db,start cache,stop db"
+	if f.log.joined() != expected {
+		t.Errorf("Calls must be %s got %s", expected, f.log.joined())
+	}
+}
+
+func TestHost_RunStopsOnSignal(t *testing.T) {
+	f := hostTestFixture{}
+	sendSignal := func() {
+		process, _ := os.FindProcess(os.Getpid())
+		process.Signal(syscall.SIGTERM)
+	}
+	f.setup(t, &hostedMock{name: "db"}, &hostedMock{name: "cache"}, &hostedMock{name: "http", onStart: sendSignal})
+	host := f.newHost(t, time.Second)
+
+	done := make(chan error)
+	go func() {
+		done <- host.Run(context.Background())
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run must stop without error got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Run must stop after SIGTERM")
+	}
+	if !strings.HasSuffix(f.log.joined(), "stop http,stop cache,stop db") {
+		t.Errorf("Services must stop in reverse order got %s", f.log.joined())
+	}
+}
+
+func TestHost_RunShutdownTimeout(t *testing.T) {
+	f := hostTestFixture{}
+	f.setup(t, &hostedMock{name: "db"}, &hostedMock{name: "cache"}, &hostedMock{name: "http", stopWait: time.Second})
+	host := f.newHost(t, 50*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := host.Run(ctx)
+
+	if err == nil || !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Errorf("Error must be the shutdown timeout got %v", err)
+	}
+}
+
+func TestServiceBuilder_AddHostedServiceNotImplemented(t *testing.T) {
+	builder := NewServiceBuilder()
+	if err := builder.AddHostedService(newNotificator, nil); err == nil {
+		t.Errorf("Register a service without Start and Stop must fail")
+	}
+}
+
+func TestOrderHostedServices(t *testing.T) {
+	graph := graphstructure.NewDirectedAcyclicGraph(false)
+	for _, key := range []string{"cache", "db", "http", "repository"} {
+		graph.AddVertice(graphstructure.Vertex{ID: key})
+	}
+	graph.AddEdge("http", "repository", 1, nil)
+	graph.AddEdge("repository", "db", 1, nil)
+
+	ordered, err := orderHostedServices(graph, []string{"http", "cache", "db"})
+	if err != nil || strings.Join(ordered, ",") != "db,http,cache" {
+		t.Errorf("Hosted services must be [db http cache] got %v, error %v", ordered, err)
+	}
+
+	graph.AddEdge("db", "http", 1, nil)
+	if _, err := orderHostedServices(graph, []string{"http", "cache", "db"}); err == nil {
+		t.Errorf("Hosted services with a cycle must not be ordered")
+	}
+}
@@ -0,0 +1,69 @@
+package dependencyinjection
+
+import (
+	"context"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/graphstructure"
+	"reflect"
+)
+
+// HostedService is a singleton started and stopped by the Host with the application
+type HostedService interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+var hostedServiceType = reflect.TypeOf((*HostedService)(nil)).Elem()
+
+// AddHostedService registers the constructor as a singleton started by the Host after the hosted
+// services it depends on, the returned service must implement HostedService
+func (builder *ServiceBuilder) AddHostedService(constructor interface{}, deferFunction func(service interface{})) error {
+	descriptor, err := newConstructorDescriptor(SingletonLifestyle, constructor, deferFunction)
+	if err != nil {
+		return err
+	}
+	if serviceType := reflect.TypeOf(constructor).Out(0); !serviceType.Implements(hostedServiceType) {
+		return fmt.Errorf("The service %v must implement HostedService", serviceType)
+	}
+	builder.register(descriptor)
+	for _, key := range builder.hostedServices {
+		if key == descriptor.key {
+			return nil
+		}
+	}
+	builder.hostedServices = append(builder.hostedServices, descriptor.key)
+	return nil
+}
+
+// orderHostedServices sorts the dependency graph topologically, the edges go from each service to its
+// dependencies so the reversed order starts every hosted service after the ones it depends on
+func orderHostedServices(graph *graphstructure.Graph, hostedServices []string) ([]string, error) {
+	order, err := graph.TopologicalSort()
+	if err != nil {
+		return nil, fmt.Errorf("Error ordering the hosted services\nError: %w", err)
+	}
+
+	isHosted := make(map[string]bool, len(hostedServices))
+	for _, key := range hostedServices {
+		isHosted[key] = true
+	}
+	ordered := make([]string, 0, len(hostedServices))
+	for i := len(order) - 1; i >= 0; i-- {
+		if isHosted[order[i]] {
+			ordered = append(ordered, order[i])
+		}
+	}
+	return ordered, nil
+}
+
+func (provider *ServiceProvider) GetHostedServices() ([]HostedService, error) {
+	services := make([]HostedService, 0, len(provider.hostedServices))
+	for _, key := range provider.hostedServices {
+		service, err := provider.GetService(key)
+		if err != nil {
+			return nil, fmt.Errorf("Error resolving the hosted service %s\nError: %w", key, err)
+		}
+		services = append(services, (*service).(HostedService))
+	}
+	return services, nil
+}
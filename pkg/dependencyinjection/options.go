@@ -0,0 +1,43 @@
+package dependencyinjection
+
+import (
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/configs"
+	"reflect"
+)
+
+var configurationKey = TypeServiceKey(reflect.TypeOf(&configs.Configuration{}))
+
+// AddOptions registers the options, a pointer to a struct, as a singleton bound to the section of the
+// registered *configs.Configuration, the fields of the given options are the defaults
+//
+//	builder.AddSingletonConstructor(configs.GetConfig, nil)
+//	builder.AddOptions(configs.DatabaseSection, &configs.DatabaseOptions{Port: 5432})
+func (builder *ServiceBuilder) AddOptions(section string, defaults interface{}) error {
+	defaultsValue := reflect.ValueOf(defaults)
+	if defaults == nil || defaultsValue.Kind() != reflect.Ptr || defaultsValue.IsNil() || defaultsValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("The options must be a not null pointer to a struct got %v", reflect.TypeOf(defaults))
+	}
+	optionsType := defaultsValue.Type().Elem()
+	serviceKey := TypeServiceKey(defaultsValue.Type())
+
+	builder.register(&serviceDescriptor{
+		key:       serviceKey,
+		lifestyle: SingletonLifestyle,
+		factory: func(resolution *resolution) (*interface{}, error) {
+			config, err := resolution.resolve(configurationKey)
+			if err != nil {
+				return nil, fmt.Errorf("Error resolving the configuration of the options %s\nError: %w", serviceKey, err)
+			}
+			options := reflect.New(optionsType)
+			options.Elem().Set(defaultsValue.Elem())
+			if err := configs.Bind((*config).(*configs.Configuration), section, options.Interface()); err != nil {
+				return nil, err
+			}
+			service := options.Interface()
+			return &service, nil
+		},
+		dependencies: []string{configurationKey},
+	})
+	return nil
+}
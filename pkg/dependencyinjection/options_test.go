@@ -0,0 +1,97 @@
+package dependencyinjection
+
+import (
+	"github.com/drprado2/go-backend-framework/pkg/configs"
+	"testing"
+)
+
+type databaseClient struct {
+	options *configs.DatabaseOptions
+}
+
+type databasePool struct {
+	options *configs.DatabaseOptions
+}
+
+func newOptionsBuilder(t *testing.T) *ServiceBuilder {
+	builder := NewServiceBuilder()
+	builder.AddSingletonConstructor(func() *configs.Configuration {
+		return &configs.Configuration{DatabaseHost: "db", DatabasePort: 5432}
+	}, nil)
+	if err := builder.AddOptions(configs.DatabaseSection, &configs.DatabaseOptions{Host: "localhost", Name: "orders"}); err != nil {
+		t.Fatalf("Error adding options\nError: %s", err)
+	}
+	builder.AddScopedConstructor(func(options *configs.DatabaseOptions) *databaseClient {
+		return &databaseClient{options: options}
+	}, nil)
+	builder.AddSingletonConstructor(func(options *configs.DatabaseOptions) *databasePool {
+		return &databasePool{options: options}
+	}, nil)
+	return builder
+}
+
+func TestServiceBuilder_AddOptions(t *testing.T) {
+	provider, err := newOptionsBuilder(t).BuildServiceProvider()
+	if err != nil {
+		t.Fatalf("Error building provider\nError: %s", err)
+	}
+
+	var options *configs.DatabaseOptions
+	if err := Resolve(provider, &options); err != nil {
+		t.Fatalf("Error resolving options\nError: %s", err)
+	}
+	if options.Host != "db" || options.Port != 5432 || options.Name != "orders" {
+		t.Errorf("Options must be bound over the defaults got %v", options)
+	}
+}
+
+func TestServiceBuilder_AddOptionsInvalid(t *testing.T) {
+	builder := NewServiceBuilder()
+	if err := builder.AddOptions(configs.DatabaseSection, configs.DatabaseOptions{}); err == nil {
+		t.Errorf("Options without pointer must fail")
+	}
+
+	builder.AddOptions(configs.DatabaseSection, &configs.DatabaseOptions{})
+	provider, _ := builder.BuildServiceProvider()
+	var options *configs.DatabaseOptions
+	if err := Resolve(provider, &options); err == nil {
+		t.Errorf("Options without the configuration registered must fail")
+	}
+}
+
+func TestServiceScope_Override(t *testing.T) {
+	provider, _ := newOptionsBuilder(t).BuildServiceProvider()
+	scope, _ := provider.CreateScope()
+	otherScope, _ := provider.CreateScope()
+
+	if err := OverrideService(scope, &configs.DatabaseOptions{Host: "test-db"}); err != nil {
+		t.Fatalf("Error overriding options\nError: %s", err)
+	}
+
+	var client, otherClient *databaseClient
+	Resolve(scope, &client)
+	Resolve(otherScope, &otherClient)
+	if client.options.Host != "test-db" {
+		t.Errorf("Scope must inject the override got %v", client.options.Host)
+	}
+	if otherClient.options.Host != "db" {
+		t.Errorf("Other scopes must keep the registered options got %v", otherClient.options.Host)
+	}
+
+	var pool, samePool, otherPool, rootPool *databasePool
+	Resolve(scope, &pool)
+	Resolve(scope, &samePool)
+	Resolve(otherScope, &otherPool)
+	Resolve(provider, &rootPool)
+	if pool.options.Host != "test-db" || pool != samePool {
+		t.Errorf("Singletons depending on the override must be created once by the scope got %v", pool.options.Host)
+	}
+	if otherPool.options.Host != "db" || otherPool != rootPool {
+		t.Errorf("Other scopes must keep the registered singleton got %v", otherPool.options.Host)
+	}
+
+	scope.Dispose()
+	if err := OverrideService(scope, &configs.DatabaseOptions{}); err == nil {
+		t.Errorf("Override on a disposed scope must fail")
+	}
+}
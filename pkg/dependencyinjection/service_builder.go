@@ -0,0 +1,278 @@
+package dependencyinjection
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+type serviceDescriptor struct {
+	key           string
+	lifestyle     int
+	factory       func(resolution *resolution) (*interface{}, error)
+	deferFunction func(service interface{})
+	// dependencies are the service keys of the constructor and decorator parameters
+	dependencies []string
+}
+
+// descriptorsByKey holds the registrations of each service key in registration order
+type descriptorsByKey map[string][]*serviceDescriptor
+
+// registeredDependency returns the key registered for the dependency, a slice of a not registered key
+// is injected with every registration of the element key
+func (descriptors descriptorsByKey) registeredDependency(dependency string) (string, bool) {
+	if _, ok := descriptors[dependency]; ok {
+		return dependency, true
+	}
+	if elementKey := strings.TrimPrefix(dependency, "[]"); elementKey != dependency {
+		if _, ok := descriptors[elementKey]; ok {
+			return elementKey, true
+		}
+	}
+	return "", false
+}
+
+type ServiceBuilder struct {
+	descriptors    descriptorsByKey
+	hostedServices []string
+}
+
+func NewServiceBuilder() *ServiceBuilder {
+	return &ServiceBuilder{
+		descriptors:    make(descriptorsByKey),
+		hostedServices: make([]string, 0),
+	}
+}
+
+// TypeServiceKey is the service key of the services registered by constructor
+func TypeServiceKey(serviceType reflect.Type) string {
+	switch serviceType.Kind() {
+	case reflect.Ptr:
+		return "*" + TypeServiceKey(serviceType.Elem())
+	case reflect.Slice:
+		return "[]" + TypeServiceKey(serviceType.Elem())
+	}
+	if serviceType.Name() != "" && serviceType.PkgPath() != "" {
+		return serviceType.PkgPath() + "." + serviceType.Name()
+	}
+	return serviceType.String()
+}
+
+// NamedServiceKey is the service key of the services registered with AddNamedConstructor
+func NamedServiceKey(serviceKey string, name string) string {
+	return serviceKey + "#" + name
+}
+
+func (builder *ServiceBuilder) register(descriptor *serviceDescriptor) {
+	builder.descriptors[descriptor.key] = append(builder.descriptors[descriptor.key], descriptor)
+}
+
+func (builder *ServiceBuilder) add(serviceKey string, lifestyle int, newFunction func() *interface{}, deferFunction func()) {
+	descriptor := &serviceDescriptor{
+		key:       serviceKey,
+		lifestyle: lifestyle,
+		factory: func(resolution *resolution) (*interface{}, error) {
+			return newFunction(), nil
+		},
+		dependencies: make([]string, 0),
+	}
+	if deferFunction != nil {
+		descriptor.deferFunction = func(service interface{}) {
+			deferFunction()
+		}
+	}
+	builder.register(descriptor)
+}
+
+// AddTransient registers a service created on every resolution, the deferFunction runs when the
+// scope or the provider that created it is disposed. Registering a key again adds one more
+// implementation, GetService returns the last one and GetServices all of them in registration order
+func (builder *ServiceBuilder) AddTransient(serviceKey string, newFunction func() *interface{}, deferFunction func()) {
+	builder.add(serviceKey, TransientLifestyle, newFunction, deferFunction)
+}
+
+// AddScoped registers a service created once by scope
+func (builder *ServiceBuilder) AddScoped(serviceKey string, newFunction func() *interface{}, deferFunction func()) {
+	builder.add(serviceKey, ScopedLifestyle, newFunction, deferFunction)
+}
+
+// AddSingleton registers a service created once by provider
+func (builder *ServiceBuilder) AddSingleton(serviceKey string, newFunction func() *interface{}, deferFunction func()) {
+	builder.add(serviceKey, SingletonLifestyle, newFunction, deferFunction)
+}
+
+func validateFunction(function interface{}, kind string) (reflect.Value, error) {
+	functionValue := reflect.ValueOf(function)
+	if function == nil || functionValue.Kind() != reflect.Func {
+		return reflect.Value{}, fmt.Errorf("The %s must be a function got %v", kind, reflect.TypeOf(function))
+	}
+	functionType := functionValue.Type()
+	if functionType.NumOut() == 0 || functionType.NumOut() > 2 ||
+		(functionType.NumOut() == 2 && functionType.Out(1) != errorType) {
+		return reflect.Value{}, fmt.Errorf("The %s %v must return the service or the service and an error", kind, functionType)
+	}
+	if functionType.IsVariadic() {
+		return reflect.Value{}, fmt.Errorf("The %s %v must not be variadic", kind, functionType)
+	}
+	return functionValue, nil
+}
+
+func parameterKeys(functionType reflect.Type, from int) []string {
+	keys := make([]string, 0, functionType.NumIn())
+	for i := from; i < functionType.NumIn(); i++ {
+		keys = append(keys, TypeServiceKey(functionType.In(i)))
+	}
+	return keys
+}
+
+// invoke calls the function resolving by type the parameters that don`t come in arguments
+func invoke(resolution *resolution, serviceKey string, function reflect.Value, arguments ...reflect.Value) (*interface{}, error) {
+	functionType := function.Type()
+	for i := len(arguments); i < functionType.NumIn(); i++ {
+		parameterType := functionType.In(i)
+		dependency, err := resolution.resolveParameter(parameterType)
+		if err != nil {
+			return nil, fmt.Errorf("Error resolving the parameter %v (%v) of the %s constructor\nError: %w", i, parameterType, serviceKey, err)
+		}
+		argument, err := toArgument(dependency, parameterType)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+	}
+	results := function.Call(arguments)
+	if len(results) == 2 && !results[1].IsNil() {
+		return nil, fmt.Errorf("Error constructing the service %s\nError: %w", serviceKey, results[1].Interface().(error))
+	}
+	service := results[0].Interface()
+	return &service, nil
+}
+
+// newConstructorDescriptor validates a constructor as func(deps...) T or func(deps...) (T, error),
+// the service is registered with the TypeServiceKey of T
+func newConstructorDescriptor(lifestyle int, constructor interface{}, deferFunction func(service interface{})) (*serviceDescriptor, error) {
+	constructorValue, err := validateFunction(constructor, "constructor")
+	if err != nil {
+		return nil, err
+	}
+	serviceKey := TypeServiceKey(constructorValue.Type().Out(0))
+
+	return &serviceDescriptor{
+		key:       serviceKey,
+		lifestyle: lifestyle,
+		factory: func(resolution *resolution) (*interface{}, error) {
+			return invoke(resolution, serviceKey, constructorValue)
+		},
+		deferFunction: deferFunction,
+		dependencies:  parameterKeys(constructorValue.Type(), 0),
+	}, nil
+}
+
+func toArgument(dependency interface{}, parameterType reflect.Type) (reflect.Value, error) {
+	if dependency == nil {
+		return reflect.Zero(parameterType), nil
+	}
+	value := reflect.ValueOf(dependency)
+	if !value.Type().AssignableTo(parameterType) {
+		return reflect.Value{}, fmt.Errorf("The service %v can`t be used as %v", value.Type(), parameterType)
+	}
+	return value, nil
+}
+
+func (builder *ServiceBuilder) addConstructor(lifestyle int, constructor interface{}, deferFunction func(service interface{})) error {
+	descriptor, err := newConstructorDescriptor(lifestyle, constructor, deferFunction)
+	if err != nil {
+		return err
+	}
+	builder.register(descriptor)
+	return nil
+}
+
+// AddTransientConstructor registers the service returned by the constructor, the constructor parameters
+// are resolved by type, the deferFunction receives the service when it is disposed. A slice parameter
+// without registration receives every registration of the element type
+func (builder *ServiceBuilder) AddTransientConstructor(constructor interface{}, deferFunction func(service interface{})) error {
+	return builder.addConstructor(TransientLifestyle, constructor, deferFunction)
+}
+
+func (builder *ServiceBuilder) AddScopedConstructor(constructor interface{}, deferFunction func(service interface{})) error {
+	return builder.addConstructor(ScopedLifestyle, constructor, deferFunction)
+}
+
+func (builder *ServiceBuilder) AddSingletonConstructor(constructor interface{}, deferFunction func(service interface{})) error {
+	return builder.addConstructor(SingletonLifestyle, constructor, deferFunction)
+}
+
+// AddNamedConstructor registers the constructor under the NamedServiceKey of its type, named services
+// are resolved with ResolveNamed and are not injected by type
+func (builder *ServiceBuilder) AddNamedConstructor(name string, lifestyle int, constructor interface{}, deferFunction func(service interface{})) error {
+	if name == "" {
+		return fmt.Errorf("The service name must not be empty")
+	}
+	descriptor, err := newConstructorDescriptor(lifestyle, constructor, deferFunction)
+	if err != nil {
+		return err
+	}
+	descriptor.key = NamedServiceKey(descriptor.key, name)
+	builder.register(descriptor)
+	return nil
+}
+
+// AddDecorator wraps every registration of T with a decorator func(inner T, deps...) T, the decorated
+// service keeps the registration lifestyle and decorators added later wrap the previous ones
+func (builder *ServiceBuilder) AddDecorator(decorator interface{}) error {
+	decoratorValue, err := validateFunction(decorator, "decorator")
+	if err != nil {
+		return err
+	}
+	decoratorType := decoratorValue.Type()
+	if decoratorType.NumIn() == 0 || decoratorType.In(0) != decoratorType.Out(0) {
+		return fmt.Errorf("The decorator %v must receive the decorated service as first parameter", decoratorType)
+	}
+	serviceKey := TypeServiceKey(decoratorType.Out(0))
+	registrations, ok := builder.descriptors[serviceKey]
+	if !ok {
+		return &ServiceNotFoundError{ServiceKey: serviceKey}
+	}
+
+	decorated := make([]*serviceDescriptor, len(registrations))
+	for i, registration := range registrations {
+		inner := registration.factory
+		descriptor := *registration
+		descriptor.dependencies = append(append(make([]string, 0), registration.dependencies...), parameterKeys(decoratorType, 1)...)
+		descriptor.factory = func(resolution *resolution) (*interface{}, error) {
+			service, err := inner(resolution)
+			if err != nil {
+				return nil, err
+			}
+			innerArgument, err := toArgument(*service, decoratorType.In(0))
+			if err != nil {
+				return nil, err
+			}
+			return invoke(resolution, serviceKey, decoratorValue, innerArgument)
+		}
+		decorated[i] = &descriptor
+	}
+	builder.descriptors[serviceKey] = decorated
+	return nil
+}
+
+// BuildServiceProvider fails with CircularDependencyError or CaptiveDependencyError when the
+// registrations can`t be resolved safely
+func (builder *ServiceBuilder) BuildServiceProvider() (ServiceProviderInterface, error) {
+	descriptors := make(descriptorsByKey, len(builder.descriptors))
+	for key, registrations := range builder.descriptors {
+		descriptors[key] = append(make([]*serviceDescriptor, 0, len(registrations)), registrations...)
+	}
+	graph, err := validateDescriptors(descriptors)
+	if err != nil {
+		return nil, err
+	}
+	hostedServices, err := orderHostedServices(graph, builder.hostedServices)
+	if err != nil {
+		return nil, err
+	}
+	return newServiceProvider(descriptors, hostedServices), nil
+}
@@ -1,5 +1,12 @@
 package dependencyinjection
 
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
 const (
 	TransientLifestyle = iota
 	ScopedLifestyle
@@ -12,7 +19,7 @@ type ServiceBuilderInterface interface {
 	AddTransient(serviceKey string, newFunction func() *interface{}, deferFunction func())
 	AddScoped(serviceKey string, newFunction func() *interface{}, deferFunction func())
 	AddSingleton(serviceKey string, newFunction func() *interface{}, deferFunction func())
-	BuildServiceProvider() ServiceProviderInterface
+	BuildServiceProvider() (ServiceProviderInterface, error)
 }
 
 type ServiceResolverInterface interface {
@@ -23,10 +30,355 @@ type ServiceResolverInterface interface {
 // Resolve singleton, transiant and scope services
 type ServiceScopeInterface interface {
 	ServiceResolverInterface
+	// Override replaces the service of the key in this scope, the singletons depending on it are created
+	// again by the scope
+	Override(serviceKey string, service interface{}) error
+	// Dispose runs the defer functions of the services created by the scope in reverse creation order
+	Dispose()
 }
 
 // Resolve singleton and transiant services, to resolve scopes use createScope
 type ServiceProviderInterface interface {
 	ServiceResolverInterface
-	CreateScope() (*ServiceScopeInterface, error)
+	CreateScope() (ServiceScopeInterface, error)
+	// GetHostedServices resolves the hosted services in start order
+	GetHostedServices() ([]HostedService, error)
+	// Dispose runs the defer functions of the singletons and of the transients created by the provider
+	Dispose()
+}
+
+type ServiceNotFoundError struct {
+	ServiceKey string
+}
+
+func (err *ServiceNotFoundError) Error() string {
+	return fmt.Sprintf("The service %s is not registered", err.ServiceKey)
+}
+
+type instance struct {
+	mutex   sync.Mutex
+	created bool
+	value   *interface{}
+}
+
+type disposables struct {
+	mutex     sync.Mutex
+	functions []func()
+	disposed  bool
+}
+
+func (d *disposables) add(deferFunction func(service interface{}), service *interface{}) {
+	if deferFunction == nil {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.functions = append(d.functions, func() {
+		var value interface{}
+		if service != nil {
+			value = *service
+		}
+		deferFunction(value)
+	})
+}
+
+func (d *disposables) dispose() {
+	d.mutex.Lock()
+	if d.disposed {
+		d.mutex.Unlock()
+		return
+	}
+	d.disposed = true
+	functions := d.functions
+	d.functions = nil
+	d.mutex.Unlock()
+
+	for i := len(functions) - 1; i >= 0; i-- {
+		functions[i]()
+	}
+}
+
+func (d *disposables) isDisposed() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.disposed
+}
+
+type ServiceProvider struct {
+	descriptors    descriptorsByKey
+	hostedServices []string
+	singletons     map[*serviceDescriptor]*instance
+	disposables    disposables
+}
+
+func newServiceProvider(descriptors descriptorsByKey, hostedServices []string) *ServiceProvider {
+	provider := &ServiceProvider{
+		descriptors:    descriptors,
+		hostedServices: hostedServices,
+		singletons:     make(map[*serviceDescriptor]*instance),
+	}
+	for _, registrations := range descriptors {
+		for _, descriptor := range registrations {
+			if descriptor.lifestyle == SingletonLifestyle {
+				provider.singletons[descriptor] = &instance{}
+			}
+		}
+	}
+	return provider
+}
+
+// GetService returns the last service registered with the key
+func (provider *ServiceProvider) GetService(serviceKey string) (*interface{}, error) {
+	return newResolution(provider, nil).resolve(serviceKey)
+}
+
+// GetServices returns every service registered with the key in registration order
+func (provider *ServiceProvider) GetServices(serviceKey string) ([]*interface{}, error) {
+	return newResolution(provider, nil).resolveAll(serviceKey)
+}
+
+func (provider *ServiceProvider) CreateScope() (ServiceScopeInterface, error) {
+	if provider.disposables.isDisposed() {
+		return nil, fmt.Errorf("The service provider is disposed")
+	}
+	return &ServiceScope{
+		provider:  provider,
+		instances: make(map[*serviceDescriptor]*instance),
+		overrides: make(map[string]*interface{}),
+	}, nil
+}
+
+func (provider *ServiceProvider) Dispose() {
+	provider.disposables.dispose()
+}
+
+// resolution tracks the services being created to report circular dependencies,
+// scope is nil when resolving from the root provider
+type resolution struct {
+	provider *ServiceProvider
+	scope    *ServiceScope
+	chain    []string
+}
+
+func newResolution(provider *ServiceProvider, scope *ServiceScope) *resolution {
+	return &resolution{
+		provider: provider,
+		scope:    scope,
+		chain:    make([]string, 0),
+	}
+}
+
+func (r *resolution) resolve(serviceKey string) (*interface{}, error) {
+	services, err := r.resolveRegistrations(serviceKey, true)
+	if err != nil {
+		return nil, err
+	}
+	return services[0], nil
+}
+
+func (r *resolution) resolveAll(serviceKey string) ([]*interface{}, error) {
+	return r.resolveRegistrations(serviceKey, false)
+}
+
+// resolveParameter resolves a constructor parameter, a slice of a not registered type receives every
+// registration of the element type
+func (r *resolution) resolveParameter(parameterType reflect.Type) (interface{}, error) {
+	serviceKey := TypeServiceKey(parameterType)
+	if registered, ok := r.provider.descriptors.registeredDependency(serviceKey); ok && registered != serviceKey {
+		services, err := r.resolveAll(registered)
+		if err != nil {
+			return nil, err
+		}
+		slice := reflect.MakeSlice(parameterType, 0, len(services))
+		for _, service := range services {
+			element, err := toArgument(*service, parameterType.Elem())
+			if err != nil {
+				return nil, err
+			}
+			slice = reflect.Append(slice, element)
+		}
+		return slice.Interface(), nil
+	}
+
+	service, err := r.resolve(serviceKey)
+	if err != nil {
+		return nil, err
+	}
+	return *service, nil
+}
+
+func (r *resolution) resolveRegistrations(serviceKey string, onlyLast bool) ([]*interface{}, error) {
+	for i, key := range r.chain {
+		if key == serviceKey {
+			return nil, fmt.Errorf("Circular dependency resolving the service %s: %s", serviceKey, strings.Join(append(r.chain[i:], serviceKey), " -> "))
+		}
+	}
+	if override, ok := r.scope.getOverride(serviceKey); ok {
+		return []*interface{}{override}, nil
+	}
+	registrations, ok := r.provider.descriptors[serviceKey]
+	if !ok {
+		return nil, &ServiceNotFoundError{ServiceKey: serviceKey}
+	}
+	if onlyLast {
+		registrations = registrations[len(registrations)-1:]
+	}
+
+	r.chain = append(r.chain, serviceKey)
+	defer func() {
+		r.chain = r.chain[:len(r.chain)-1]
+	}()
+
+	services := make([]*interface{}, 0, len(registrations))
+	for _, descriptor := range registrations {
+		service, err := r.create(descriptor)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, service)
+	}
+	return services, nil
+}
+
+func (r *resolution) create(descriptor *serviceDescriptor) (*interface{}, error) {
+	switch descriptor.lifestyle {
+	case SingletonLifestyle:
+		// a singleton depending on an override of the scope, like a database factory receiving overridden
+		// options, is created once by the scope with the override
+		if r.scope.overridesDependencyOf(r.provider.descriptors, descriptor) {
+			return r.getOrCreate(r.scope.getInstance(descriptor), descriptor, &r.scope.disposables)
+		}
+		// singletons only depend on the root provider so scoped services never get captured
+		singletonResolution := &resolution{provider: r.provider, chain: r.chain}
+		return singletonResolution.getOrCreate(r.provider.singletons[descriptor], descriptor, &r.provider.disposables)
+	case ScopedLifestyle:
+		if r.scope == nil {
+			return nil, fmt.Errorf("The scoped service %s can`t be resolved from the root provider, use CreateScope", descriptor.key)
+		}
+		return r.getOrCreate(r.scope.getInstance(descriptor), descriptor, &r.scope.disposables)
+	default:
+		owner := &r.provider.disposables
+		if r.scope != nil {
+			owner = &r.scope.disposables
+		}
+		service, err := descriptor.factory(r)
+		if err != nil {
+			return nil, err
+		}
+		owner.add(descriptor.deferFunction, service)
+		return service, nil
+	}
+}
+
+func (r *resolution) getOrCreate(cached *instance, descriptor *serviceDescriptor, owner *disposables) (*interface{}, error) {
+	cached.mutex.Lock()
+	defer cached.mutex.Unlock()
+	if cached.created {
+		return cached.value, nil
+	}
+	if owner.isDisposed() {
+		return nil, fmt.Errorf("The service %s can`t be created after dispose", descriptor.key)
+	}
+	service, err := descriptor.factory(r)
+	if err != nil {
+		return nil, err
+	}
+	cached.value = service
+	cached.created = true
+	owner.add(descriptor.deferFunction, service)
+	return cached.value, nil
+}
+
+type ServiceScope struct {
+	provider    *ServiceProvider
+	mutex       sync.Mutex
+	instances   map[*serviceDescriptor]*instance
+	overrides   map[string]*interface{}
+	disposables disposables
+}
+
+func (scope *ServiceScope) Override(serviceKey string, service interface{}) error {
+	if scope.disposables.isDisposed() {
+		return fmt.Errorf("The scope is disposed")
+	}
+	scope.mutex.Lock()
+	defer scope.mutex.Unlock()
+	scope.overrides[serviceKey] = &service
+	return nil
+}
+
+// getOverride is safe on a nil scope, the root provider has no overrides
+func (scope *ServiceScope) getOverride(serviceKey string) (*interface{}, bool) {
+	if scope == nil {
+		return nil, false
+	}
+	scope.mutex.Lock()
+	defer scope.mutex.Unlock()
+	service, ok := scope.overrides[serviceKey]
+	return service, ok
+}
+
+// overridesDependencyOf is safe on a nil scope, it follows the dependencies of the descriptor through
+// every registration until it finds one overridden by the scope
+func (scope *ServiceScope) overridesDependencyOf(descriptors descriptorsByKey, descriptor *serviceDescriptor) bool {
+	if scope == nil {
+		return false
+	}
+	scope.mutex.Lock()
+	hasOverrides := len(scope.overrides) > 0
+	scope.mutex.Unlock()
+	if !hasOverrides {
+		return false
+	}
+
+	visited := make(map[string]bool)
+	pending := append(make([]string, 0), descriptor.dependencies...)
+	for len(pending) > 0 {
+		dependency := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if _, ok := scope.getOverride(dependency); ok {
+			return true
+		}
+		registered, ok := descriptors.registeredDependency(dependency)
+		if !ok || visited[registered] {
+			continue
+		}
+		visited[registered] = true
+		if _, ok := scope.getOverride(registered); ok {
+			return true
+		}
+		for _, registration := range descriptors[registered] {
+			pending = append(pending, registration.dependencies...)
+		}
+	}
+	return false
+}
+
+func (scope *ServiceScope) getInstance(descriptor *serviceDescriptor) *instance {
+	scope.mutex.Lock()
+	defer scope.mutex.Unlock()
+	cached, ok := scope.instances[descriptor]
+	if !ok {
+		cached = &instance{}
+		scope.instances[descriptor] = cached
+	}
+	return cached
+}
+
+func (scope *ServiceScope) GetService(serviceKey string) (*interface{}, error) {
+	if scope.disposables.isDisposed() {
+		return nil, fmt.Errorf("The scope is disposed")
+	}
+	return newResolution(scope.provider, scope).resolve(serviceKey)
+}
+
+func (scope *ServiceScope) GetServices(serviceKey string) ([]*interface{}, error) {
+	if scope.disposables.isDisposed() {
+		return nil, fmt.Errorf("The scope is disposed")
+	}
+	return newResolution(scope.provider, scope).resolveAll(serviceKey)
+}
+
+func (scope *ServiceScope) Dispose() {
+	scope.disposables.dispose()
 }
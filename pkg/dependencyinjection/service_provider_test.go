@@ -0,0 +1,171 @@
+package dependencyinjection
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+type counterService struct {
+	id int
+}
+
+func newCounterFunction(counter *int) func() *interface{} {
+	return func() *interface{} {
+		*counter++
+		var service interface{} = &counterService{id: *counter}
+		return &service
+	}
+}
+
+func serviceId(t *testing.T, service *interface{}) int {
+	counter, ok := (*service).(*counterService)
+	if !ok {
+		t.Fatalf("Service must be a counterService got %v", *service)
+	}
+	return counter.id
+}
+
+func TestServiceProvider_Singleton(t *testing.T) {
+	created := 0
+	builder := NewServiceBuilder()
+	builder.AddSingleton("counter", newCounterFunction(&created), nil)
+	provider, _ := builder.BuildServiceProvider()
+
+	first, _ := provider.GetService("counter")
+	scope, _ := provider.CreateScope()
+	second, _ := scope.GetService("counter")
+
+	if created != 1 || serviceId(t, first) != serviceId(t, second) {
+		t.Errorf("Singleton must be created once got %v creations", created)
+	}
+}
+
+func TestServiceProvider_SingletonConcurrent(t *testing.T) {
+	created := 0
+	builder := NewServiceBuilder()
+	builder.AddSingleton("counter", newCounterFunction(&created), nil)
+	provider, _ := builder.BuildServiceProvider()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			provider.GetService("counter")
+		}()
+	}
+	wg.Wait()
+
+	if created != 1 {
+		t.Errorf("Singleton must be created once got %v creations", created)
+	}
+}
+
+func TestServiceProvider_Transient(t *testing.T) {
+	created := 0
+	builder := NewServiceBuilder()
+	builder.AddTransient("counter", newCounterFunction(&created), nil)
+	provider, _ := builder.BuildServiceProvider()
+
+	first, _ := provider.GetService("counter")
+	second, _ := provider.GetService("counter")
+
+	if created != 2 || serviceId(t, first) == serviceId(t, second) {
+		t.Errorf("Transient must be created on every call got %v creations", created)
+	}
+}
+
+func TestServiceProvider_Scoped(t *testing.T) {
+	created := 0
+	builder := NewServiceBuilder()
+	builder.AddScoped("counter", newCounterFunction(&created), nil)
+	provider, _ := builder.BuildServiceProvider()
+
+	if _, err := provider.GetService("counter"); err == nil {
+		t.Errorf("Scoped service must not be resolved by the root provider")
+	}
+
+	scopeA, _ := provider.CreateScope()
+	scopeB, _ := provider.CreateScope()
+	firstA, _ := scopeA.GetService("counter")
+	secondA, _ := scopeA.GetService("counter")
+	firstB, _ := scopeB.GetService("counter")
+
+	if serviceId(t, firstA) != serviceId(t, secondA) {
+		t.Errorf("Scoped service must be the same in the scope")
+	}
+	if serviceId(t, firstA) == serviceId(t, firstB) {
+		t.Errorf("Scoped service must be different between scopes")
+	}
+}
+
+func TestServiceProvider_ScopeDisposeInReverseOrder(t *testing.T) {
+	disposed := make([]string, 0)
+	created := 0
+	builder := NewServiceBuilder()
+	builder.AddScoped("first", newCounterFunction(&created), func() { disposed = append(disposed, "first") })
+	builder.AddTransient("second", newCounterFunction(&created), func() { disposed = append(disposed, "second") })
+	builder.AddScoped("third", newCounterFunction(&created), func() { disposed = append(disposed, "third") })
+	builder.AddSingleton("singleton", newCounterFunction(&created), func() { disposed = append(disposed, "singleton") })
+	provider, _ := builder.BuildServiceProvider()
+
+	scope, _ := provider.CreateScope()
+	scope.GetService("first")
+	scope.GetService("singleton")
+	scope.GetService("second")
+	scope.GetService("third")
+	scope.GetService("first")
+	scope.Dispose()
+	scope.Dispose()
+
+	if len(disposed) != 3 || disposed[0] != "third" || disposed[1] != "second" || disposed[2] != "first" {
+		t.Errorf("Disposed must be [third second first] got %v", disposed)
+	}
+	if _, err := scope.GetService("first"); err == nil {
+		t.Errorf("Disposed scope must not resolve services")
+	}
+
+	provider.Dispose()
+	if len(disposed) != 4 || disposed[3] != "singleton" {
+		t.Errorf("Provider dispose must run the singleton defer got %v", disposed)
+	}
+}
+
+func TestServiceProvider_NotFound(t *testing.T) {
+	provider, _ := NewServiceBuilder().BuildServiceProvider()
+
+	_, err := provider.GetService("missing")
+	var notFoundErr *ServiceNotFoundError
+	if !errors.As(err, &notFoundErr) || notFoundErr.ServiceKey != "missing" {
+		t.Errorf("Error must be ServiceNotFoundError got %v", err)
+	}
+}
+
+func TestServiceProvider_MultipleRegistrations(t *testing.T) {
+	created := 0
+	builder := NewServiceBuilder()
+	builder.AddSingleton("counter", newCounterFunction(&created), nil)
+	builder.AddTransient("counter", newCounterFunction(&created), nil)
+	builder.AddScoped("counter", newCounterFunction(&created), nil)
+	provider, _ := builder.BuildServiceProvider()
+	scope, _ := provider.CreateScope()
+
+	services, err := scope.GetServices("counter")
+	if err != nil || len(services) != 3 {
+		t.Fatalf("Services must have the 3 registrations got %v %v", services, err)
+	}
+	for i, service := range services {
+		if serviceId(t, service) != i+1 {
+			t.Errorf("Services must be in registration order got %v at %v", serviceId(t, service), i)
+		}
+	}
+
+	last, _ := scope.GetService("counter")
+	if serviceId(t, last) != serviceId(t, services[2]) {
+		t.Errorf("GetService must return the last registration")
+	}
+	if _, err := provider.GetServices("counter"); err == nil {
+		t.Errorf("GetServices with a scoped registration must fail on the root provider")
+	}
+}
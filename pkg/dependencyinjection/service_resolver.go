@@ -0,0 +1,97 @@
+package dependencyinjection
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// GetServiceByType returns the service registered with the TypeServiceKey of the type
+func GetServiceByType(resolver ServiceResolverInterface, serviceType reflect.Type) (interface{}, error) {
+	service, err := resolver.GetService(TypeServiceKey(serviceType))
+	if err != nil {
+		return nil, err
+	}
+	return *service, nil
+}
+
+// Resolve fills the target, a pointer to the service type, with the registered service
+//
+//	var service *MyService
+//	err := Resolve(provider, &service)
+func Resolve(resolver ServiceResolverInterface, target interface{}) error {
+	return resolveKey(resolver, target, TypeServiceKey)
+}
+
+// ResolveNamed fills the target with the service registered by AddNamedConstructor with the name
+func ResolveNamed(resolver ServiceResolverInterface, name string, target interface{}) error {
+	return resolveKey(resolver, target, func(serviceType reflect.Type) string {
+		return NamedServiceKey(TypeServiceKey(serviceType), name)
+	})
+}
+
+func pointerTarget(target interface{}) (reflect.Value, error) {
+	value := reflect.ValueOf(target)
+	if target == nil || value.Kind() != reflect.Ptr || value.IsNil() {
+		return reflect.Value{}, fmt.Errorf("The target must be a not null pointer to the service type got %v", reflect.TypeOf(target))
+	}
+	return value, nil
+}
+
+func resolveKey(resolver ServiceResolverInterface, target interface{}, serviceKey func(serviceType reflect.Type) string) error {
+	targetValue, err := pointerTarget(target)
+	if err != nil {
+		return err
+	}
+	serviceType := targetValue.Type().Elem()
+
+	service, err := resolver.GetService(serviceKey(serviceType))
+	if err != nil {
+		return err
+	}
+	value, err := toArgument(*service, serviceType)
+	if err != nil {
+		return err
+	}
+	targetValue.Elem().Set(value)
+	return nil
+}
+
+// ResolveAll fills the target, a pointer to a slice of the service type, with every registration
+// of the service type in registration order
+//
+//	var stages []beforeexecute.Interface
+//	err := ResolveAll(scope, &stages)
+func ResolveAll(resolver ServiceResolverInterface, target interface{}) error {
+	targetValue, err := pointerTarget(target)
+	if err != nil {
+		return err
+	}
+	sliceType := targetValue.Type().Elem()
+	if sliceType.Kind() != reflect.Slice {
+		return fmt.Errorf("The target must be a pointer to a slice of the service type got %v", targetValue.Type())
+	}
+
+	services, err := resolver.GetServices(TypeServiceKey(sliceType.Elem()))
+	if err != nil {
+		return err
+	}
+	slice := reflect.MakeSlice(sliceType, 0, len(services))
+	for _, service := range services {
+		value, err := toArgument(*service, sliceType.Elem())
+		if err != nil {
+			return err
+		}
+		slice = reflect.Append(slice, value)
+	}
+	targetValue.Elem().Set(slice)
+	return nil
+}
+
+// OverrideService replaces in the scope the service registered with the TypeServiceKey of the service
+// type, use Override with the TypeServiceKey of the interface to replace interface registrations
+func OverrideService(scope ServiceScopeInterface, service interface{}) error {
+	if service == nil {
+		return fmt.Errorf("The service must not be null")
+	}
+	return scope.Override(TypeServiceKey(reflect.TypeOf(service)), service)
+}
@@ -0,0 +1,339 @@
+package dependencyinjection
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type repositoryInterface interface {
+	Find() string
+}
+
+type repository struct {
+	name string
+}
+
+func (r *repository) Find() string {
+	return r.name
+}
+
+type notificator struct{}
+
+type userService struct {
+	repository  repositoryInterface
+	notificator *notificator
+}
+
+func newRepository() repositoryInterface {
+	return &repository{name: "users"}
+}
+
+func newNotificator() *notificator {
+	return &notificator{}
+}
+
+func newUserService(repository repositoryInterface, notificator *notificator) *userService {
+	return &userService{repository: repository, notificator: notificator}
+}
+
+func TestServiceBuilder_ConstructorInjection(t *testing.T) {
+	builder := NewServiceBuilder()
+	builder.AddSingletonConstructor(newRepository, nil)
+	builder.AddScopedConstructor(newNotificator, nil)
+	builder.AddTransientConstructor(newUserService, nil)
+	provider, _ := builder.BuildServiceProvider()
+	scope, _ := provider.CreateScope()
+
+	var service *userService
+	if err := Resolve(scope, &service); err != nil {
+		t.Fatalf("Error resolving service\nError: %s", err)
+	}
+	if service.repository.Find() != "users" || service.notificator == nil {
+		t.Errorf("Service dependencies must be injected got %v", service)
+	}
+
+	var other *userService
+	Resolve(scope, &other)
+	if other == service || other.notificator != service.notificator {
+		t.Errorf("Transient must be new and the scoped dependency must be shared in the scope")
+	}
+}
+
+func TestServiceBuilder_ConstructorWithError(t *testing.T) {
+	builder := NewServiceBuilder()
+	builder.AddSingletonConstructor(func() (repositoryInterface, error) {
+		return nil, errors.New("database unavailable")
+	}, nil)
+	provider, _ := builder.BuildServiceProvider()
+
+	var repository repositoryInterface
+	if err := Resolve(provider, &repository); err == nil || !strings.Contains(err.Error(), "database unavailable") {
+		t.Errorf("Error must contain the constructor error got %v", err)
+	}
+}
+
+func TestServiceBuilder_MissingDependency(t *testing.T) {
+	builder := NewServiceBuilder()
+	builder.AddScopedConstructor(newNotificator, nil)
+	builder.AddTransientConstructor(newUserService, nil)
+	provider, _ := builder.BuildServiceProvider()
+	scope, _ := provider.CreateScope()
+
+	var service *userService
+	err := Resolve(scope, &service)
+	var notFoundErr *ServiceNotFoundError
+	if !errors.As(err, &notFoundErr) || !strings.Contains(notFoundErr.ServiceKey, "repositoryInterface") {
+		t.Errorf("Error must be ServiceNotFoundError of repositoryInterface got %v", err)
+	}
+}
+
+func TestServiceBuilder_InvalidConstructor(t *testing.T) {
+	builder := NewServiceBuilder()
+	if err := builder.AddTransientConstructor("not a function", nil); err == nil {
+		t.Errorf("Register a string must fail")
+	}
+	if err := builder.AddTransientConstructor(func() {}, nil); err == nil {
+		t.Errorf("Register a constructor without return must fail")
+	}
+	if err := builder.AddTransientConstructor(func() (int, int) { return 0, 0 }, nil); err == nil {
+		t.Errorf("Register a constructor with second return different of error must fail")
+	}
+}
+
+func TestServiceBuilder_ConstructorDeferReceivesService(t *testing.T) {
+	disposed := make([]string, 0)
+	builder := NewServiceBuilder()
+	builder.AddScopedConstructor(newRepository, func(service interface{}) {
+		disposed = append(disposed, service.(repositoryInterface).Find())
+	})
+	provider, _ := builder.BuildServiceProvider()
+	scope, _ := provider.CreateScope()
+
+	var repository repositoryInterface
+	Resolve(scope, &repository)
+	scope.Dispose()
+
+	if len(disposed) != 1 || disposed[0] != "users" {
+		t.Errorf("Defer must receive the service got %v", disposed)
+	}
+}
+
+type cycleA struct{}
+type cycleB struct{}
+type cycleC struct{}
+
+func TestServiceBuilder_CircularDependency(t *testing.T) {
+	builder := NewServiceBuilder()
+	builder.AddTransientConstructor(func(b *cycleB) *cycleA { return &cycleA{} }, nil)
+	builder.AddTransientConstructor(func(a *cycleA) *cycleB { return &cycleB{} }, nil)
+	provider, err := builder.BuildServiceProvider()
+
+	var cycleErr *CircularDependencyError
+	if provider != nil || !errors.As(err, &cycleErr) {
+		t.Fatalf("Build must fail with CircularDependencyError got %v", err)
+	}
+	if len(cycleErr.Cycle) != 3 || cycleErr.Cycle[0] != cycleErr.Cycle[2] || cycleErr.Cycle[0] == cycleErr.Cycle[1] {
+		t.Errorf("Cycle must contain the full path got %v", cycleErr.Cycle)
+	}
+}
+
+func TestServiceBuilder_CircularDependencyServices(t *testing.T) {
+	builder := NewServiceBuilder()
+	builder.AddTransientConstructor(func(b *cycleB) *cycleA { return &cycleA{} }, nil)
+	builder.AddTransientConstructor(func(a *cycleA, c *cycleC) *cycleB { return &cycleB{} }, nil)
+	builder.AddTransientConstructor(func(a *cycleA) *cycleC { return &cycleC{} }, nil)
+	builder.AddTransientConstructor(func(c *cycleC) *userService { return &userService{} }, nil)
+	_, err := builder.BuildServiceProvider()
+
+	var cycleErr *CircularDependencyError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("Build must fail with CircularDependencyError got %v", err)
+	}
+	expected := []string{TypeServiceKey(reflect.TypeOf(&cycleA{})), TypeServiceKey(reflect.TypeOf(&cycleB{})), TypeServiceKey(reflect.TypeOf(&cycleC{}))}
+	sort.Strings(expected)
+	if !reflect.DeepEqual(cycleErr.Services, expected) {
+		t.Errorf("Services must be %v got %v", expected, cycleErr.Services)
+	}
+}
+
+func TestServiceBuilder_CaptiveDependency(t *testing.T) {
+	builder := NewServiceBuilder()
+	builder.AddSingletonConstructor(newRepository, nil)
+	builder.AddScopedConstructor(newNotificator, nil)
+	builder.AddTransientConstructor(newUserService, nil)
+	builder.AddSingletonConstructor(func(service *userService) *cycleA { return &cycleA{} }, nil)
+	_, err := builder.BuildServiceProvider()
+
+	var captiveErr *CaptiveDependencyError
+	if !errors.As(err, &captiveErr) {
+		t.Fatalf("Build must fail with CaptiveDependencyError got %v", err)
+	}
+	expected := []string{TypeServiceKey(reflect.TypeOf(&cycleA{})), TypeServiceKey(reflect.TypeOf(&userService{})), TypeServiceKey(reflect.TypeOf(&notificator{}))}
+	if strings.Join(captiveErr.Path, ",") != strings.Join(expected, ",") {
+		t.Errorf("Path must be %v got %v", expected, captiveErr.Path)
+	}
+}
+
+func TestServiceBuilder_ScopedDependingOnScoped(t *testing.T) {
+	builder := NewServiceBuilder()
+	builder.AddSingletonConstructor(newRepository, nil)
+	builder.AddScopedConstructor(newNotificator, nil)
+	builder.AddScopedConstructor(newUserService, nil)
+	if _, err := builder.BuildServiceProvider(); err != nil {
+		t.Errorf("Scoped service depending on scoped and singleton services must be valid got %v", err)
+	}
+}
+
+func TestResolve_InvalidTarget(t *testing.T) {
+	provider, _ := NewServiceBuilder().BuildServiceProvider()
+	var service *userService
+	if err := Resolve(provider, service); err == nil {
+		t.Errorf("Resolve without pointer must fail")
+	}
+}
+
+type stageInterface interface {
+	Name() string
+}
+
+type stage struct {
+	name string
+}
+
+func (s *stage) Name() string {
+	return s.name
+}
+
+type loggingStage struct {
+	inner stageInterface
+	log   *[]string
+}
+
+func (s *loggingStage) Name() string {
+	*s.log = append(*s.log, s.inner.Name())
+	return "logged " + s.inner.Name()
+}
+
+type pipeline struct {
+	stages []stageInterface
+}
+
+func registerStages(builder *ServiceBuilder) {
+	builder.AddTransientConstructor(func() stageInterface { return &stage{name: "validation"} }, nil)
+	builder.AddTransientConstructor(func() stageInterface { return &stage{name: "authorization"} }, nil)
+	builder.AddScopedConstructor(func(n *notificator) stageInterface { return &stage{name: "notification"} }, nil)
+	builder.AddScopedConstructor(newNotificator, nil)
+}
+
+func stageNames(stages []stageInterface) string {
+	names := make([]string, len(stages))
+	for i, s := range stages {
+		names[i] = s.Name()
+	}
+	return strings.Join(names, ",")
+}
+
+func TestResolveAll(t *testing.T) {
+	builder := NewServiceBuilder()
+	registerStages(builder)
+	provider, _ := builder.BuildServiceProvider()
+	scope, _ := provider.CreateScope()
+
+	var stages []stageInterface
+	if err := ResolveAll(scope, &stages); err != nil {
+		t.Fatalf("Error resolving stages\nError: %s", err)
+	}
+	if stageNames(stages) != "validation,authorization,notification" {
+		t.Errorf("Stages must be in registration order got %s", stageNames(stages))
+	}
+
+	var last stageInterface
+	Resolve(scope, &last)
+	if last.Name() != "notification" {
+		t.Errorf("Resolve must return the last registration got %s", last.Name())
+	}
+}
+
+func TestServiceBuilder_SliceParameterReceivesEveryRegistration(t *testing.T) {
+	builder := NewServiceBuilder()
+	registerStages(builder)
+	builder.AddScopedConstructor(func(stages []stageInterface) *pipeline { return &pipeline{stages: stages} }, nil)
+	provider, _ := builder.BuildServiceProvider()
+	scope, _ := provider.CreateScope()
+
+	var service *pipeline
+	if err := Resolve(scope, &service); err != nil {
+		t.Fatalf("Error resolving pipeline\nError: %s", err)
+	}
+	if stageNames(service.stages) != "validation,authorization,notification" {
+		t.Errorf("Pipeline must receive every stage got %s", stageNames(service.stages))
+	}
+}
+
+func TestServiceBuilder_SliceParameterCaptiveDependency(t *testing.T) {
+	builder := NewServiceBuilder()
+	registerStages(builder)
+	builder.AddSingletonConstructor(func(stages []stageInterface) *pipeline { return &pipeline{stages: stages} }, nil)
+
+	var captiveErr *CaptiveDependencyError
+	if _, err := builder.BuildServiceProvider(); !errors.As(err, &captiveErr) {
+		t.Errorf("Build must fail with CaptiveDependencyError got %v", err)
+	}
+}
+
+func TestResolveNamed(t *testing.T) {
+	builder := NewServiceBuilder()
+	builder.AddSingletonConstructor(newRepository, nil)
+	builder.AddNamedConstructor("orders", SingletonLifestyle, func() repositoryInterface { return &repository{name: "orders"} }, nil)
+	provider, _ := builder.BuildServiceProvider()
+
+	var orders, users repositoryInterface
+	if err := ResolveNamed(provider, "orders", &orders); err != nil || orders.Find() != "orders" {
+		t.Errorf("Named repository must be orders got %v %v", orders, err)
+	}
+	if err := Resolve(provider, &users); err != nil || users.Find() != "users" {
+		t.Errorf("Repository by type must be users got %v %v", users, err)
+	}
+	var missing repositoryInterface
+	var notFoundErr *ServiceNotFoundError
+	if err := ResolveNamed(provider, "products", &missing); !errors.As(err, &notFoundErr) {
+		t.Errorf("Error must be ServiceNotFoundError got %v", err)
+	}
+}
+
+func TestServiceBuilder_AddDecorator(t *testing.T) {
+	log := make([]string, 0)
+	builder := NewServiceBuilder()
+	builder.AddTransientConstructor(func() stageInterface { return &stage{name: "validation"} }, nil)
+	builder.AddTransientConstructor(func() stageInterface { return &stage{name: "authorization"} }, nil)
+	builder.AddSingletonConstructor(func() *[]string { return &log }, nil)
+	if err := builder.AddDecorator(func(inner stageInterface, log *[]string) stageInterface {
+		return &loggingStage{inner: inner, log: log}
+	}); err != nil {
+		t.Fatalf("Error adding decorator\nError: %s", err)
+	}
+	provider, _ := builder.BuildServiceProvider()
+
+	var stages []stageInterface
+	ResolveAll(provider, &stages)
+	if stageNames(stages) != "logged validation,logged authorization" {
+		t.Errorf("Every registration must be decorated got %s", stageNames(stages))
+	}
+	if strings.Join(log, ",") != "validation,authorization" {
+		t.Errorf("Decorator must call the inner service got %v", log)
+	}
+}
+
+func TestServiceBuilder_AddDecoratorInvalid(t *testing.T) {
+	builder := NewServiceBuilder()
+	var notFoundErr *ServiceNotFoundError
+	if err := builder.AddDecorator(func(inner stageInterface) stageInterface { return inner }); !errors.As(err, &notFoundErr) {
+		t.Errorf("Decorate a not registered service must fail with ServiceNotFoundError got %v", err)
+	}
+	if err := builder.AddDecorator(func(name string) stageInterface { return nil }); err == nil {
+		t.Errorf("Decorator without the decorated service as first parameter must fail")
+	}
+}
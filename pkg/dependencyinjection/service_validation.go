@@ -0,0 +1,123 @@
+package dependencyinjection
+
+import (
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/graphstructure"
+	"sort"
+	"strings"
+)
+
+type CircularDependencyError struct {
+	// Cycle starts and ends with the same service key
+	Cycle []string
+	// Services has every service key of the strongly connected component of the cycle, sorted
+	Services []string
+}
+
+func (err *CircularDependencyError) Error() string {
+	return fmt.Sprintf("Circular dependency between the services %s", strings.Join(err.Cycle, " -> "))
+}
+
+// CaptiveDependencyError reports a singleton that holds a scoped service, directly or through transients
+type CaptiveDependencyError struct {
+	Path []string
+}
+
+func (err *CaptiveDependencyError) Error() string {
+	return fmt.Sprintf("The singleton %s captures the scoped service %s: %s", err.Path[0], err.Path[len(err.Path)-1], strings.Join(err.Path, " -> "))
+}
+
+func sortedServiceKeys(descriptors descriptorsByKey) []string {
+	keys := make([]string, 0, len(descriptors))
+	for key := range descriptors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// newDependencyGraph has one vertex by service key holding its registrations and one edge from each key
+// to each dependency, the missing dependencies are vertices without registrations
+func newDependencyGraph(descriptors descriptorsByKey) (*graphstructure.Graph, error) {
+	graph := graphstructure.NewDirectedAcyclicGraph(false)
+	keys := sortedServiceKeys(descriptors)
+	for _, key := range keys {
+		if err := graph.AddVertice(graphstructure.Vertex{ID: key, GenericData: descriptors[key]}); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range keys {
+		added := make(map[string]bool)
+		for _, registration := range descriptors[key] {
+			for _, dependency := range registration.dependencies {
+				registered, ok := descriptors.registeredDependency(dependency)
+				if !ok {
+					registered = dependency
+					if !graph.ContainsVertice(registered) {
+						graph.AddVertice(graphstructure.Vertex{ID: registered})
+					}
+				}
+				if added[registered] {
+					continue
+				}
+				added[registered] = true
+				if err := graph.AddEdge(key, registered, 1, nil); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return graph, nil
+}
+
+// validateDescriptors returns the dependency graph of the valid descriptors
+func validateDescriptors(descriptors descriptorsByKey) (*graphstructure.Graph, error) {
+	graph, err := newDependencyGraph(descriptors)
+	if err != nil {
+		return nil, fmt.Errorf("Error building the service dependency graph\nError: %w", err)
+	}
+	if cycles := graph.GetCycles(); len(cycles) > 0 {
+		for _, component := range graph.StronglyConnectedComponents() {
+			for _, key := range component {
+				if key == cycles[0][0] {
+					return nil, &CircularDependencyError{Cycle: cycles[0], Services: component}
+				}
+			}
+		}
+	}
+
+	for _, key := range sortedServiceKeys(descriptors) {
+		for _, descriptor := range descriptors[key] {
+			if descriptor.lifestyle != SingletonLifestyle {
+				continue
+			}
+			if path := findCapturedScope(descriptors, descriptor, []string{key}); path != nil {
+				return nil, &CaptiveDependencyError{Path: path}
+			}
+		}
+	}
+	return graph, nil
+}
+
+// findCapturedScope follows the transient dependencies of the service until it finds a scoped one,
+// singleton dependencies are validated by themselves
+func findCapturedScope(descriptors descriptorsByKey, descriptor *serviceDescriptor, path []string) []string {
+	for _, dependency := range descriptor.dependencies {
+		registered, ok := descriptors.registeredDependency(dependency)
+		if !ok {
+			continue
+		}
+		dependencyPath := append(path[:len(path):len(path)], registered)
+		for _, registration := range descriptors[registered] {
+			switch registration.lifestyle {
+			case ScopedLifestyle:
+				return dependencyPath
+			case TransientLifestyle:
+				if captured := findCapturedScope(descriptors, registration, dependencyPath); captured != nil {
+					return captured
+				}
+			}
+		}
+	}
+	return nil
+}
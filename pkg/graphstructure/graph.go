@@ -276,46 +276,47 @@ func (g *Graph) existsCycle(vertex *Vertex, alreadyVisiteds []string, visitedInC
 	return false
 }
 
+// GetCycles returns the cycles closed by the back edges of a depth first search, each cycle
+// starts and ends with the same vertex ID
 func (g *Graph) GetCycles() [][]string {
-	alreadyVisited := make([]string, 0, len(g.vertexes))
+	alreadyVisited := make(map[string]bool, len(g.vertexes))
 	visitedControl := stackstructure.NewStack(3)
 	result := make([][]string, 0)
 
 	for _, vertex := range g.vertexes {
-		g.getCycles(vertex, alreadyVisited, visitedControl, result)
+		result = g.getCycles(vertex, alreadyVisited, visitedControl, result)
 	}
 
 	return result
 }
 
-func (g *Graph) getCycles(vertex *Vertex, alreadyVisited []string, visitedInCurrentScan *stackstructure.Stack, result [][]string) {
-	if visitedInCurrentScan.PositionOfElement(vertex.ID, func(elemA interface{}, elemB interface{}) bool {
+func (g *Graph) getCycles(vertex *Vertex, alreadyVisited map[string]bool, visitedInCurrentScan *stackstructure.Stack, result [][]string) [][]string {
+	if position := visitedInCurrentScan.PositionOfElement(vertex.ID, func(elemA interface{}, elemB interface{}) bool {
 		idA := elemA.(string)
 		idB := elemB.(string)
 		return idA == idB
-	}) > -1 {
-		cycle := visitedInCurrentScan.CopyToSlice()
-		cycleIds := make([]string, 0, len(cycle)+1)
+	}); position > -1 {
+		scan := visitedInCurrentScan.CopyToSlice()
+		cycle := scan[len(scan)-1-position:]
+		cycleIds := make([]string, len(cycle)+1)
 		for i, id := range cycle {
 			cycleIds[i] = id.(string)
 		}
-		cycleIds[len(cycle)+1] = vertex.ID
-		result = append(result, cycleIds)
-		return
+		cycleIds[len(cycle)] = vertex.ID
+		return append(result, cycleIds)
 	}
-	for _, id := range alreadyVisited {
-		if id == vertex.ID {
-			return
-		}
+	if alreadyVisited[vertex.ID] {
+		return result
 	}
-	visitedInCurrentScan.StackUp(vertex)
-	alreadyVisited = append(alreadyVisited, vertex.ID)
+	visitedInCurrentScan.StackUp(vertex.ID)
+	alreadyVisited[vertex.ID] = true
 
 	for _, edge := range vertex.edgesAdjacentVertices {
-		g.getCycles(edge.Head, alreadyVisited, visitedInCurrentScan, result)
+		result = g.getCycles(edge.Head, alreadyVisited, visitedInCurrentScan, result)
 	}
 
 	visitedInCurrentScan.Unstack()
+	return result
 }
 
 func (g *Graph) BreadthFirstSearch(fromVertexId string, toVertexId string) (*Vertex, error) {
@@ -451,81 +452,3 @@ func (g *Graph) getDependents(vertex *Vertex, edges []Edge, dependents *structdo
 		}
 	}
 }
-
-func (g *Graph) FindShortestPath(fromVerticeId string, toVerticeId string) ([]PathPoint, error) {
-	verticeFrom, foundFrom := g.vertexes[fromVerticeId]
-	_, foundTo := g.vertexes[toVerticeId]
-
-	if !foundFrom || !foundTo {
-		return nil, fmt.Errorf("VerticeFrom or verticeTo doesn`t exist in the graph")
-	}
-
-	sealedVertexes := make(map[string]PathPoint)
-	pathPoints := make(map[string]PathPoint)
-	pathPoints[verticeFrom.ID] = PathPoint{
-		Vertex:       *verticeFrom,
-		WeightUpHere: 0,
-	}
-	if finalPath := g.findShortestPath(pathPoints[verticeFrom.ID], toVerticeId, pathPoints, sealedVertexes); finalPath == nil {
-		return nil, fmt.Errorf("There is no way from %s to %s", fromVerticeId, toVerticeId)
-	}
-	result := make([]PathPoint, 0, 10)
-	for path := sealedVertexes[toVerticeId]; path.Vertex.ID != fromVerticeId; path = sealedVertexes[path.Vertex.ID] {
-		result = append(result, path)
-	}
-	result = append(result, sealedVertexes[fromVerticeId])
-	for x, z := 0, len(result)-1; x < z; x, z = x+1, z-1 {
-		result[x], result[z] = result[z], result[x]
-	}
-	return result, nil
-}
-
-func getPathPointSortedList() *structdoublylinkedlist.List {
-	equalityFunc := func(elemA interface{}, elemB interface{}) bool {
-		pathA, okA := elemA.(PathPoint)
-		pathB, okB := elemB.(PathPoint)
-
-		if !okA || !okB {
-			return false
-		}
-
-		return pathA.Vertex.ID == pathB.Vertex.ID
-	}
-	sortFunc := func(elemA interface{}, elemB interface{}) int {
-		pathA := elemA.(PathPoint)
-		pathB := elemB.(PathPoint)
-
-		return pathA.WeightUpHere - pathB.WeightUpHere
-	}
-	return structdoublylinkedlist.NewSortedList(equalityFunc, sortFunc, reflect.TypeOf(PathPoint{}))
-}
-
-func (g *Graph) findShortestPath(currentPath PathPoint, toVerticeId string, paths map[string]PathPoint, sealedVertexes map[string]PathPoint) *PathPoint {
-	sealedVertexes[currentPath.Vertex.ID] = currentPath
-
-	pathList := getPathPointSortedList()
-	for _, edge := range currentPath.Vertex.edgesAdjacentVertices {
-		if _, sealed := sealedVertexes[edge.Head.ID]; sealed {
-			continue
-		}
-		distance := currentPath.WeightUpHere + edge.Weight
-		if estimate, ok := paths[edge.Head.ID]; !ok || distance < estimate.WeightUpHere {
-			paths[edge.Head.ID] = PathPoint{
-				Vertex:       currentPath.Vertex,
-				WeightUpHere: distance,
-			}
-		}
-		if edge.Head.ID == toVerticeId {
-			path := paths[edge.Head.ID]
-			return &path
-		}
-		pathList.Add(paths[edge.Head.ID])
-	}
-	for path := pathList.Unshift(); path != nil; path = pathList.Unshift() {
-		cPath := path.(PathPoint)
-		if finalPath := g.findShortestPath(cPath, toVerticeId, paths, sealedVertexes); finalPath != nil {
-			return finalPath
-		}
-	}
-	return nil
-}
@@ -0,0 +1,169 @@
+package graphstructure
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Unreachable is the distance between two vertices without a path
+const Unreachable = math.MaxInt
+
+// DistanceMatrix has the smallest sum of weights between every pair of vertices, Distances[i][j] goes
+// from IDs[i] to IDs[j] and the IDs are sorted
+type DistanceMatrix struct {
+	IDs       []string
+	Distances [][]int
+	vertices  []*Vertex
+	indexes   map[string]int
+	// next[i][j] is the index of the vertex after i in the path to j
+	next [][]int
+}
+
+// AllPairsShortestPaths computes the distance matrix using the Floyd-Warshall algorithm in O(V³), it
+// accepts negative weights and returns a NegativeCycleError when the graph has a negative cycle
+func (g *Graph) AllPairsShortestPaths() (*DistanceMatrix, error) {
+	matrix := &DistanceMatrix{
+		IDs:       make([]string, 0, len(g.vertexes)),
+		Distances: make([][]int, len(g.vertexes)),
+		vertices:  make([]*Vertex, len(g.vertexes)),
+		indexes:   make(map[string]int, len(g.vertexes)),
+		next:      make([][]int, len(g.vertexes)),
+	}
+	for id := range g.vertexes {
+		matrix.IDs = append(matrix.IDs, id)
+	}
+	sort.Strings(matrix.IDs)
+	for i, id := range matrix.IDs {
+		matrix.vertices[i] = g.vertexes[id]
+		matrix.indexes[id] = i
+	}
+
+	for i, vertex := range matrix.vertices {
+		matrix.Distances[i] = make([]int, len(matrix.vertices))
+		matrix.next[i] = make([]int, len(matrix.vertices))
+		for j := range matrix.Distances[i] {
+			matrix.Distances[i][j] = Unreachable
+			matrix.next[i][j] = -1
+		}
+		matrix.Distances[i][i] = 0
+		matrix.next[i][i] = i
+		for _, edge := range vertex.edgesAdjacentVertices {
+			j := matrix.indexes[edge.Head.ID]
+			if edge.Weight < matrix.Distances[i][j] {
+				matrix.Distances[i][j] = edge.Weight
+				matrix.next[i][j] = j
+			}
+		}
+	}
+
+	distances := matrix.Distances
+	for k := range distances {
+		for i := range distances {
+			if distances[i][k] == Unreachable {
+				continue
+			}
+			for j := range distances {
+				if distances[k][j] == Unreachable {
+					continue
+				}
+				if distance := distances[i][k] + distances[k][j]; distance < distances[i][j] {
+					// stops at the first negative cycle before the distances around it overflow
+					if i == j && distance < 0 {
+						return nil, matrix.negativeCycle(i, k)
+					}
+					distances[i][j] = distance
+					matrix.next[i][j] = matrix.next[i][k]
+				}
+			}
+		}
+	}
+	return matrix, nil
+}
+
+// negativeCycle returns the negative cycle inside the walk from i to k and back to i, the paths of the
+// column k do not change while k is the intermediate vertex
+func (m *DistanceMatrix) negativeCycle(i int, k int) error {
+	walk := []int{i}
+	for _, target := range []int{k, i} {
+		for steps := 0; steps == 0 || walk[len(walk)-1] != target; steps++ {
+			hop := m.next[walk[len(walk)-1]][target]
+			if hop == -1 || steps == len(m.vertices) {
+				return fmt.Errorf("The vertex %s is in a negative cycle but the path to %s is broken", m.IDs[i], m.IDs[target])
+			}
+			walk = append(walk, hop)
+		}
+	}
+
+	// a closed walk with negative weight has at least one negative simple cycle
+	stack := make([]int, 0, len(walk))
+	positions := make(map[int]int, len(walk))
+	for _, vertex := range walk {
+		position, seen := positions[vertex]
+		if !seen {
+			positions[vertex] = len(stack)
+			stack = append(stack, vertex)
+			continue
+		}
+		cycle := append(stack[position:len(stack):len(stack)], vertex)
+		weight := 0
+		for x := 1; x < len(cycle); x++ {
+			weight += m.edgeWeight(cycle[x-1], cycle[x])
+		}
+		if weight < 0 {
+			ids := make([]string, len(cycle))
+			for x, index := range cycle {
+				ids[x] = m.IDs[index]
+			}
+			return &NegativeCycleError{Cycle: ids}
+		}
+		for _, removed := range stack[position+1:] {
+			delete(positions, removed)
+		}
+		stack = stack[:position+1]
+	}
+	return fmt.Errorf("The vertex %s is in a negative cycle but the walk through %s has none", m.IDs[i], m.IDs[k])
+}
+
+// edgeWeight returns the smallest weight of the edges from to
+func (m *DistanceMatrix) edgeWeight(from int, to int) int {
+	weight := Unreachable
+	for _, edge := range m.vertices[from].edgesAdjacentVertices {
+		if edge.Head.ID == m.IDs[to] && edge.Weight < weight {
+			weight = edge.Weight
+		}
+	}
+	return weight
+}
+
+// Distance returns false when a vertex does not exist or there is no path between them
+func (m *DistanceMatrix) Distance(fromVerticeId string, toVerticeId string) (int, bool) {
+	from, foundFrom := m.indexes[fromVerticeId]
+	to, foundTo := m.indexes[toVerticeId]
+	if !foundFrom || !foundTo || m.Distances[from][to] == Unreachable {
+		return 0, false
+	}
+	return m.Distances[from][to], true
+}
+
+// Path returns the shortest path with the same points as FindShortestPath
+func (m *DistanceMatrix) Path(fromVerticeId string, toVerticeId string) ([]PathPoint, error) {
+	from, foundFrom := m.indexes[fromVerticeId]
+	to, foundTo := m.indexes[toVerticeId]
+	if !foundFrom || !foundTo {
+		return nil, fmt.Errorf("VerticeFrom or verticeTo doesn`t exist in the graph")
+	}
+	if m.next[from][to] == -1 {
+		return nil, fmt.Errorf("There is no way from %s to %s", fromVerticeId, toVerticeId)
+	}
+
+	path := []PathPoint{{Vertex: *m.vertices[from]}}
+	for current := from; current != to; current = m.next[current][to] {
+		next := m.next[current][to]
+		path = append(path, PathPoint{
+			Vertex:       *m.vertices[next],
+			WeightUpHere: m.Distances[from][next],
+		})
+	}
+	return path, nil
+}
@@ -0,0 +1,86 @@
+package graphstructure
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGraph_AllPairsShortestPaths(t *testing.T) {
+	graph := buildRoadsGraph()
+	matrix, err := graph.AllPairsShortestPaths()
+	if err != nil {
+		t.Fatalf("Error computing the distances\nError: %s", err)
+	}
+	if len(matrix.IDs) != 7 || matrix.IDs[0] != "a" || len(matrix.Distances) != 7 {
+		t.Fatalf("Matrix must have the 7 sorted vertices got %v", matrix.IDs)
+	}
+
+	for _, from := range matrix.IDs {
+		for _, to := range matrix.IDs {
+			distance, reachable := matrix.Distance(from, to)
+			expected, err := graph.FindShortestPath(from, to)
+			if (err == nil) != reachable || (reachable && distance != pathWeight(expected)) {
+				t.Errorf("Distance from %s to %s must match FindShortestPath got %v", from, to, distance)
+			}
+		}
+	}
+	if path, err := matrix.Path("f", "b"); err != nil || pathDescription(path) != "f0 a1 c3 b4 " {
+		t.Errorf("Path must be f0 a1 c3 b4 got %v and %v", pathDescription(path), err)
+	}
+	if _, err := matrix.Path("a", "g"); err == nil || err.Error() != "There is no way from a to g" {
+		t.Errorf("Path to an unreachable vertex must fail got %v", err)
+	}
+	if _, reachable := matrix.Distance("a", "z"); reachable {
+		t.Errorf("Missing vertex must not be reachable")
+	}
+}
+
+func TestGraph_AllPairsShortestPathsNegativeWeights(t *testing.T) {
+	graph := NewDirectedAcyclicGraph(false)
+	addTestVertices(graph, "a", "b", "c")
+	graph.AddEdge("a", "b", 4, nil)
+	graph.AddEdge("a", "c", 2, nil)
+	graph.AddEdge("b", "c", -3, nil)
+
+	matrix, err := graph.AllPairsShortestPaths()
+	if err != nil {
+		t.Fatalf("Error computing the distances\nError: %s", err)
+	}
+	if distance, _ := matrix.Distance("a", "c"); distance != 1 {
+		t.Errorf("Distance from a to c must be 1 got %v", distance)
+	}
+
+	graph.AddEdge("c", "b", 1, nil)
+	var cycleErr *NegativeCycleError
+	if _, err := graph.AllPairsShortestPaths(); !errors.As(err, &cycleErr) || strings.Join(cycleErr.Cycle, ",") != "c,b,c" {
+		t.Errorf("Error must be the NegativeCycleError c -> b -> c got %v", err)
+	}
+}
+
+func TestGraph_AllPairsShortestPathsNegativeCycleOverflow(t *testing.T) {
+	graph := NewDirectedCyclicGraph()
+	addTestVertices(graph, "a", "b", "c", "d")
+	graph.AddEdge("a", "b", math.MinInt/2, nil)
+	graph.AddEdge("b", "c", 0, nil)
+	graph.AddEdge("c", "a", 0, nil)
+	graph.AddEdge("c", "d", 1, nil)
+
+	var cycleErr *NegativeCycleError
+	if _, err := graph.AllPairsShortestPaths(); !errors.As(err, &cycleErr) || strings.Join(cycleErr.Cycle, ",") != "c,a,b,c" {
+		t.Errorf("Error must be the NegativeCycleError c -> a -> b -> c got %v", err)
+	}
+}
+
+func TestGraph_AllPairsShortestPathsNegativeSelfLoop(t *testing.T) {
+	graph := NewDirectedCyclicGraph()
+	addTestVertices(graph, "a", "b")
+	graph.AddEdge("a", "b", 1, nil)
+	graph.AddEdge("b", "b", -1, nil)
+
+	var cycleErr *NegativeCycleError
+	if _, err := graph.AllPairsShortestPaths(); !errors.As(err, &cycleErr) || strings.Join(cycleErr.Cycle, ",") != "b,b" {
+		t.Errorf("Error must be the NegativeCycleError b -> b got %v", err)
+	}
+}
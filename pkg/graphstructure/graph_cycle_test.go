@@ -1 +1,63 @@
 package graphstructure
+
+import (
+	"strings"
+	"testing"
+)
+
+func addTestVertices(g *Graph, ids ...string) {
+	for _, id := range ids {
+		g.AddVertice(Vertex{ID: id})
+	}
+}
+
+func TestGraph_GetCyclesWithoutCycle(t *testing.T) {
+	graph := NewDirectedAcyclicGraph(false)
+	addTestVertices(graph, "a", "b", "c")
+	graph.AddEdge("a", "b", 0, nil)
+	graph.AddEdge("b", "c", 0, nil)
+	graph.AddEdge("a", "c", 0, nil)
+
+	if cycles := graph.GetCycles(); len(cycles) != 0 {
+		t.Errorf("Cycles must be empty got %v", cycles)
+	}
+	if graph.ExistsCycle() {
+		t.Errorf("Graph must not have cycle")
+	}
+}
+
+func TestGraph_GetCycles(t *testing.T) {
+	graph := NewDirectedAcyclicGraph(false)
+	addTestVertices(graph, "a", "b", "c", "d")
+	graph.AddEdge("a", "b", 0, nil)
+	graph.AddEdge("b", "c", 0, nil)
+	graph.AddEdge("c", "a", 0, nil)
+	graph.AddEdge("c", "d", 0, nil)
+
+	cycles := graph.GetCycles()
+	if len(cycles) != 1 {
+		t.Fatalf("Cycles must have 1 cycle got %v", cycles)
+	}
+	cycle := cycles[0]
+	if len(cycle) != 4 || cycle[0] != cycle[3] {
+		t.Fatalf("Cycle must start and end with the same vertex got %v", cycle)
+	}
+	path := strings.Join(append(cycle[:3], cycle[:3]...), "")
+	if !strings.Contains(path, "abc") {
+		t.Errorf("Cycle must be a rotation of a, b, c got %v", cycle)
+	}
+	if !graph.ExistsCycle() {
+		t.Errorf("Graph must have cycle")
+	}
+}
+
+func TestGraph_GetCyclesSelfLoop(t *testing.T) {
+	graph := NewDirectedAcyclicGraph(false)
+	addTestVertices(graph, "a")
+	graph.AddEdge("a", "a", 0, nil)
+
+	cycles := graph.GetCycles()
+	if len(cycles) != 1 || len(cycles[0]) != 2 || cycles[0][0] != "a" || cycles[0][1] != "a" {
+		t.Errorf("Cycles must be [[a a]] got %v", cycles)
+	}
+}
@@ -0,0 +1,190 @@
+package graphstructure
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+type ExecutionMode int
+
+const (
+	// FailFast cancels the running tasks and starts no other task after the first failure
+	FailFast ExecutionMode = iota
+	// ContinueOnError keeps running the vertices that do not depend on a failed vertex
+	ContinueOnError
+)
+
+type VertexStatus int
+
+const (
+	VertexSucceeded VertexStatus = iota
+	VertexFailed
+	// VertexSkipped is a vertex not run because a vertex it depends on failed
+	VertexSkipped
+	// VertexCanceled is a vertex not run because the execution was canceled
+	VertexCanceled
+)
+
+type VertexResult struct {
+	VertexID string
+	Status   VertexStatus
+	Err      error
+	Started  time.Time
+	Finished time.Time
+}
+
+// ExecutionReport has one result by vertex, Completed has the IDs of the run vertices in the order
+// their tasks finished
+type ExecutionReport struct {
+	Results   map[string]*VertexResult
+	Completed []string
+	canceled  error
+}
+
+// Err joins the errors of the failed vertices and the cancellation of the context
+func (r *ExecutionReport) Err() error {
+	errs := make([]error, 0)
+	for _, id := range r.Completed {
+		if result := r.Results[id]; result.Status == VertexFailed {
+			errs = append(errs, fmt.Errorf("Error running the vertex %s\nError: %w", id, result.Err))
+		}
+	}
+	if r.canceled != nil {
+		errs = append(errs, r.canceled)
+	}
+	return errors.Join(errs...)
+}
+
+type ExecutionOptions struct {
+	// Workers is the number of tasks running at the same time, GOMAXPROCS when it is not positive
+	Workers int
+	Mode    ExecutionMode
+}
+
+type vertexCompletion struct {
+	id       string
+	err      error
+	started  time.Time
+	finished time.Time
+}
+
+// runTask returns the panic of the task as its error so the vertex fails instead of the process
+func runTask(ctx context.Context, vertex Vertex, task func(ctx context.Context, vertex Vertex) error) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if recoveredErr, ok := recovered.(error); ok {
+				err = fmt.Errorf("Panic running the vertex task\nError: %w", recoveredErr)
+				return
+			}
+			err = fmt.Errorf("Panic running the vertex task: %v", recovered)
+		}
+	}()
+	return task(ctx, vertex)
+}
+
+// Execute runs the task of every vertex on a pool of workers, a vertex starts as soon as the tasks of
+// all the tails of its incoming edges succeeded, the returned error is the report error
+func (g *Graph) Execute(ctx context.Context, options ExecutionOptions, task func(ctx context.Context, vertex Vertex) error) (*ExecutionReport, error) {
+	if _, err := g.TopologicalSort(); err != nil {
+		return nil, fmt.Errorf("Error ordering the graph to execute\nError: %w", err)
+	}
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	executionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	jobs := make(chan *Vertex)
+	completions := make(chan vertexCompletion)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for vertex := range jobs {
+				started := time.Now()
+				err := runTask(executionCtx, *vertex, task)
+				completions <- vertexCompletion{id: vertex.ID, err: err, started: started, finished: time.Now()}
+			}
+		}()
+	}
+
+	inDegree := g.inDegrees()
+	ready := &idHeap{}
+	for id, degree := range inDegree {
+		if degree == 0 {
+			*ready = append(*ready, id)
+		}
+	}
+	heap.Init(ready)
+
+	report := &ExecutionReport{
+		Results:   make(map[string]*VertexResult, len(g.vertexes)),
+		Completed: make([]string, 0, len(g.vertexes)),
+	}
+	running := 0
+	for {
+		// running lower than workers means one worker is waiting for a job
+		for executionCtx.Err() == nil && ready.Len() > 0 && running < workers {
+			jobs <- g.vertexes[heap.Pop(ready).(string)]
+			running++
+		}
+		if running == 0 {
+			break
+		}
+
+		completion := <-completions
+		running--
+		result := &VertexResult{
+			VertexID: completion.id,
+			Status:   VertexSucceeded,
+			Err:      completion.err,
+			Started:  completion.started,
+			Finished: completion.finished,
+		}
+		report.Results[completion.id] = result
+		report.Completed = append(report.Completed, completion.id)
+		if completion.err != nil {
+			result.Status = VertexFailed
+			if options.Mode == FailFast {
+				cancel()
+			}
+			continue
+		}
+		for _, edge := range g.vertexes[completion.id].edgesAdjacentVertices {
+			inDegree[edge.Head.ID]--
+			if inDegree[edge.Head.ID] == 0 {
+				heap.Push(ready, edge.Head.ID)
+			}
+		}
+	}
+	close(jobs)
+
+	// the vertices not run that depend on a failed vertex are skipped, the others were stopped by the
+	// cancellation of the caller or of the fail fast mode
+	pending := make([]*Vertex, 0)
+	for _, id := range report.Completed {
+		if report.Results[id].Status == VertexFailed {
+			pending = append(pending, g.vertexes[id])
+		}
+	}
+	for len(pending) > 0 {
+		vertex := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		for _, edge := range vertex.edgesAdjacentVertices {
+			if _, ok := report.Results[edge.Head.ID]; !ok {
+				report.Results[edge.Head.ID] = &VertexResult{VertexID: edge.Head.ID, Status: VertexSkipped}
+				pending = append(pending, edge.Head)
+			}
+		}
+	}
+	for id := range g.vertexes {
+		if _, ok := report.Results[id]; !ok {
+			report.Results[id] = &VertexResult{VertexID: id, Status: VertexCanceled}
+		}
+	}
+	report.canceled = ctx.Err()
+	return report, report.Err()
+}
@@ -0,0 +1,216 @@
+package graphstructure
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// buildPipelineGraph is extract -> (clean, enrich) -> load and report not depending on anyone
+func buildPipelineGraph() *Graph {
+	graph := NewDirectedAcyclicGraph(false)
+	addTestVertices(graph, "extract", "clean", "enrich", "load", "report")
+	graph.AddEdge("extract", "clean", 0, nil)
+	graph.AddEdge("extract", "enrich", 0, nil)
+	graph.AddEdge("clean", "load", 0, nil)
+	graph.AddEdge("enrich", "load", 0, nil)
+	return graph
+}
+
+type executionLog struct {
+	mutex    sync.Mutex
+	finished map[string]bool
+}
+
+func (l *executionLog) finish(id string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.finished[id] = true
+}
+
+func (l *executionLog) isFinished(id string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.finished[id]
+}
+
+func TestGraph_ExecuteRespectsDependencies(t *testing.T) {
+	graph := buildPipelineGraph()
+	log := &executionLog{finished: make(map[string]bool)}
+	dependencies := map[string][]string{"clean": {"extract"}, "enrich": {"extract"}, "load": {"clean", "enrich"}}
+	reportFinished := make(chan struct{})
+	cleanStarted := make(chan struct{})
+
+	report, err := graph.Execute(context.Background(), ExecutionOptions{Workers: 3}, func(ctx context.Context, vertex Vertex) error {
+		for _, dependency := range dependencies[vertex.ID] {
+			if !log.isFinished(dependency) {
+				t.Errorf("Vertex %s started before %s finished", vertex.ID, dependency)
+			}
+		}
+		// extract holds its dependents until the independent report ran and enrich holds load until
+		// clean is running next to it
+		switch vertex.ID {
+		case "extract":
+			<-reportFinished
+		case "report":
+			close(reportFinished)
+		case "clean":
+			close(cleanStarted)
+		case "enrich":
+			<-cleanStarted
+		}
+		log.finish(vertex.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error executing the graph\nError: %s", err)
+	}
+	if len(report.Completed) != 5 {
+		t.Errorf("All the vertices must complete got %v", report.Completed)
+	}
+	for id, result := range report.Results {
+		if result.Status != VertexSucceeded || result.Finished.Before(result.Started) {
+			t.Errorf("Vertex %s must succeed got %v", id, result.Status)
+		}
+	}
+}
+
+func TestGraph_ExecuteInParallel(t *testing.T) {
+	graph := NewDirectedAcyclicGraph(false)
+	addTestVertices(graph, "a", "b")
+	barrier := sync.WaitGroup{}
+	barrier.Add(2)
+
+	_, err := graph.Execute(context.Background(), ExecutionOptions{Workers: 2}, func(ctx context.Context, vertex Vertex) error {
+		barrier.Done()
+		waited := make(chan struct{})
+		go func() {
+			barrier.Wait()
+			close(waited)
+		}()
+		select {
+		case <-waited:
+			return nil
+		case <-time.After(time.Second):
+			return errors.New("the other vertex is not running")
+		}
+	})
+	if err != nil {
+		t.Errorf("Independent vertices must run at the same time\nError: %s", err)
+	}
+}
+
+func TestGraph_ExecuteContinueOnError(t *testing.T) {
+	graph := buildPipelineGraph()
+	failure := errors.New("enrich failed")
+
+	report, err := graph.Execute(context.Background(), ExecutionOptions{Workers: 1, Mode: ContinueOnError}, func(ctx context.Context, vertex Vertex) error {
+		if vertex.ID == "enrich" {
+			return failure
+		}
+		return nil
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Error must wrap the task error got %v", err)
+	}
+	expected := map[string]VertexStatus{
+		"extract": VertexSucceeded,
+		"clean":   VertexSucceeded,
+		"enrich":  VertexFailed,
+		"load":    VertexSkipped,
+		"report":  VertexSucceeded,
+	}
+	for id, status := range expected {
+		if report.Results[id].Status != status {
+			t.Errorf("Vertex %s status must be %v got %v", id, status, report.Results[id].Status)
+		}
+	}
+}
+
+func TestGraph_ExecuteFailFast(t *testing.T) {
+	graph := buildPipelineGraph()
+	failure := errors.New("extract failed")
+
+	report, err := graph.Execute(context.Background(), ExecutionOptions{Workers: 1, Mode: FailFast}, func(ctx context.Context, vertex Vertex) error {
+		if vertex.ID == "extract" {
+			return failure
+		}
+		return nil
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Error must wrap the task error got %v", err)
+	}
+	if len(report.Completed) != 1 {
+		t.Errorf("Only extract must run got %v", report.Completed)
+	}
+	for _, id := range []string{"clean", "enrich", "load"} {
+		if status := report.Results[id].Status; status != VertexSkipped {
+			t.Errorf("Dependent %s of the failed vertex must be skipped got %v", id, status)
+		}
+	}
+	if status := report.Results["report"].Status; status != VertexCanceled {
+		t.Errorf("Report must be canceled got %v", status)
+	}
+}
+
+func TestGraph_ExecuteCanceled(t *testing.T) {
+	graph := buildPipelineGraph()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	report, err := graph.Execute(ctx, ExecutionOptions{Workers: 1}, func(taskCtx context.Context, vertex Vertex) error {
+		if vertex.ID == "extract" {
+			cancel()
+			<-taskCtx.Done()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Error must be the cancellation got %v", err)
+	}
+	if report.Results["extract"].Status != VertexSucceeded || report.Results["load"].Status != VertexCanceled {
+		t.Errorf("Only extract must run got %v", report.Completed)
+	}
+}
+
+func TestGraph_ExecuteWithCycle(t *testing.T) {
+	graph := NewDirectedCyclicGraph()
+	addTestVertices(graph, "a", "b")
+	graph.AddEdge("a", "b", 0, nil)
+	graph.AddEdge("b", "a", 0, nil)
+
+	report, err := graph.Execute(context.Background(), ExecutionOptions{}, func(ctx context.Context, vertex Vertex) error {
+		t.Errorf("No vertex must run")
+		return nil
+	})
+	if report != nil || err == nil {
+		t.Errorf("Execute of a cyclic graph must fail got %v", report)
+	}
+}
+
+func TestGraph_ExecutePanicFailsVertex(t *testing.T) {
+	graph := buildPipelineGraph()
+
+	report, err := graph.Execute(context.Background(), ExecutionOptions{Workers: 2, Mode: ContinueOnError}, func(ctx context.Context, vertex Vertex) error {
+		if vertex.ID == "clean" {
+			panic("clean exploded")
+		}
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "clean exploded") {
+		t.Errorf("Error must have the panic value got %v", err)
+	}
+	if result := report.Results["clean"]; result.Status != VertexFailed || !strings.Contains(result.Err.Error(), "clean exploded") {
+		t.Errorf("Panicking vertex must fail with the panic value got %v, error %v", result.Status, result.Err)
+	}
+	if status := report.Results["load"].Status; status != VertexSkipped {
+		t.Errorf("Dependent of the panicking vertex must be skipped got %v", status)
+	}
+	for _, id := range []string{"extract", "enrich", "report"} {
+		if status := report.Results[id].Status; status != VertexSucceeded {
+			t.Errorf("Vertex %s must succeed got %v", id, status)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package graphstructure
+
+import (
+	"fmt"
+	"strings"
+)
+
+type blockedEdge struct {
+	tail string
+	head string
+}
+
+func pathKey(path []PathPoint) string {
+	ids := make([]string, len(path))
+	for i, point := range path {
+		ids[i] = point.Vertex.ID
+	}
+	return strings.Join(ids, "\x00")
+}
+
+func pathWeight(path []PathPoint) int {
+	return path[len(path)-1].WeightUpHere
+}
+
+// sharesRoot tells if the path starts with the same vertices as the root
+func sharesRoot(path []PathPoint, root []PathPoint) bool {
+	if len(path) <= len(root) {
+		return false
+	}
+	for i := range root {
+		if path[i].Vertex.ID != root[i].Vertex.ID {
+			return false
+		}
+	}
+	return true
+}
+
+// KShortestPaths returns up to k paths without repeated vertices ordered by ascending weight using
+// Yen's algorithm, the paths with the same weight come with the fewest vertices first. The weights must
+// not be negative
+func (g *Graph) KShortestPaths(fromVerticeId string, toVerticeId string, k int) ([][]PathPoint, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("The number of paths must be at least 1 got %v", k)
+	}
+	first, err := g.FindShortestPath(fromVerticeId, toVerticeId)
+	if err != nil {
+		return nil, err
+	}
+	verticeTo := g.vertexes[toVerticeId]
+	noHeuristic := func(vertex Vertex, target Vertex) int {
+		return 0
+	}
+
+	paths := [][]PathPoint{first}
+	candidates := make([][]PathPoint, 0)
+	known := map[string]bool{pathKey(first): true}
+	for len(paths) < k {
+		last := paths[len(paths)-1]
+		for i := 0; i < len(last)-1; i++ {
+			// the new path leaves the root of the last path at the spur vertex by an edge not used yet
+			root := last[:i+1]
+			blockedEdges := make(map[blockedEdge]bool)
+			for _, path := range paths {
+				if sharesRoot(path, root) {
+					blockedEdges[blockedEdge{path[i].Vertex.ID, path[i+1].Vertex.ID}] = true
+				}
+			}
+			blockedVertices := make(map[string]bool, i)
+			for _, point := range root[:i] {
+				blockedVertices[point.Vertex.ID] = true
+			}
+
+			spur := search(g.vertexes[root[i].Vertex.ID], verticeTo, noHeuristic, func(edge *Edge) bool {
+				return blockedVertices[edge.Head.ID] || blockedEdges[blockedEdge{edge.Tail.ID, edge.Head.ID}]
+			})
+			if spur == nil {
+				continue
+			}
+			candidate := make([]PathPoint, 0, len(root)+len(spur)-1)
+			candidate = append(candidate, root...)
+			for _, point := range spur[1:] {
+				point.WeightUpHere += root[i].WeightUpHere
+				candidate = append(candidate, point)
+			}
+			if key := pathKey(candidate); !known[key] {
+				known[key] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		best := 0
+		for i, candidate := range candidates {
+			weight, bestWeight := pathWeight(candidate), pathWeight(candidates[best])
+			if weight < bestWeight || (weight == bestWeight && len(candidate) < len(candidates[best])) ||
+				(weight == bestWeight && len(candidate) == len(candidates[best]) && pathKey(candidate) < pathKey(candidates[best])) {
+				best = i
+			}
+		}
+		paths = append(paths, candidates[best])
+		candidates = append(candidates[:best], candidates[best+1:]...)
+	}
+	return paths, nil
+}
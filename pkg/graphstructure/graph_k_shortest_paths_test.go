@@ -0,0 +1,75 @@
+package graphstructure
+
+import (
+	"testing"
+)
+
+// buildYenGraph is the example graph of Yen's algorithm
+func buildYenGraph() *Graph {
+	graph := NewDirectedAcyclicGraph(false)
+	addTestVertices(graph, "c", "d", "e", "f", "g", "h")
+	graph.AddEdge("c", "d", 3, nil)
+	graph.AddEdge("c", "e", 2, nil)
+	graph.AddEdge("d", "f", 4, nil)
+	graph.AddEdge("e", "d", 1, nil)
+	graph.AddEdge("e", "f", 2, nil)
+	graph.AddEdge("e", "g", 3, nil)
+	graph.AddEdge("f", "g", 2, nil)
+	graph.AddEdge("f", "h", 1, nil)
+	graph.AddEdge("g", "h", 2, nil)
+	return graph
+}
+
+func TestGraph_KShortestPaths(t *testing.T) {
+	paths, err := buildYenGraph().KShortestPaths("c", "h", 3)
+	if err != nil {
+		t.Fatalf("Error finding the paths\nError: %s", err)
+	}
+	expected := []string{"c0 e2 f4 h5 ", "c0 e2 g5 h7 ", "c0 d3 f7 h8 "}
+	if len(paths) != len(expected) {
+		t.Fatalf("Paths must be %v got %v", expected, len(paths))
+	}
+	for i, path := range paths {
+		if description := pathDescription(path); description != expected[i] {
+			t.Errorf("Path %v must be %v got %v", i, expected[i], description)
+		}
+	}
+}
+
+func TestGraph_KShortestPathsAll(t *testing.T) {
+	paths, err := buildYenGraph().KShortestPaths("c", "h", 100)
+	if err != nil {
+		t.Fatalf("Error finding the paths\nError: %s", err)
+	}
+	// every loopless path from c to h
+	if len(paths) != 7 {
+		t.Fatalf("Paths must be 7 got %v", len(paths))
+	}
+	known := make(map[string]bool)
+	for i, path := range paths {
+		if known[pathKey(path)] {
+			t.Errorf("Path %v is repeated", pathDescription(path))
+		}
+		known[pathKey(path)] = true
+		if i > 0 && pathWeight(path) < pathWeight(paths[i-1]) {
+			t.Errorf("Paths must be ordered by weight got %v after %v", pathDescription(path), pathDescription(paths[i-1]))
+		}
+		visited := make(map[string]bool)
+		for _, point := range path {
+			if visited[point.Vertex.ID] {
+				t.Errorf("Path %v repeats the vertex %s", pathDescription(path), point.Vertex.ID)
+			}
+			visited[point.Vertex.ID] = true
+		}
+	}
+}
+
+func TestGraph_KShortestPathsInvalid(t *testing.T) {
+	graph := buildYenGraph()
+	if _, err := graph.KShortestPaths("c", "h", 0); err == nil {
+		t.Errorf("Zero paths must fail")
+	}
+	if _, err := graph.KShortestPaths("h", "c", 2); err == nil || err.Error() != "There is no way from h to c" {
+		t.Errorf("Unreachable target must fail got %v", err)
+	}
+}
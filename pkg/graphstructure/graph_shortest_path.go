@@ -0,0 +1,206 @@
+package graphstructure
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NegativeCycleError reports a cycle whose weights sum less than zero, it makes the shortest path
+// undefined because every lap around it lowers the weight
+type NegativeCycleError struct {
+	// Cycle starts and ends with the same vertex ID
+	Cycle []string
+}
+
+func (err *NegativeCycleError) Error() string {
+	return fmt.Sprintf("The graph has the negative cycle %s", strings.Join(err.Cycle, " -> "))
+}
+
+// Heuristic estimates the weight from the vertex to the target, A* returns the shortest path when the
+// estimate is never greater than the real weight
+type Heuristic func(vertex Vertex, target Vertex) int
+
+type searchItem struct {
+	vertex   *Vertex
+	weight   int
+	priority int
+}
+
+type searchHeap []searchItem
+
+func (h searchHeap) Len() int           { return len(h) }
+func (h searchHeap) Less(i, j int) bool { return h[i].priority < h[j].priority }
+func (h searchHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *searchHeap) Push(item interface{}) {
+	*h = append(*h, item.(searchItem))
+}
+
+func (h *searchHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+func (g *Graph) pathEnds(fromVerticeId string, toVerticeId string) (*Vertex, *Vertex, error) {
+	verticeFrom, foundFrom := g.vertexes[fromVerticeId]
+	verticeTo, foundTo := g.vertexes[toVerticeId]
+	if !foundFrom || !foundTo {
+		return nil, nil, fmt.Errorf("VerticeFrom or verticeTo doesn`t exist in the graph")
+	}
+	return verticeFrom, verticeTo, nil
+}
+
+// buildPath follows the previous vertices from the target back to the source
+func buildPath(to *Vertex, previous map[string]*Vertex, weights map[string]int) []PathPoint {
+	result := make([]PathPoint, 0, 10)
+	for vertex := to; vertex != nil; vertex = previous[vertex.ID] {
+		result = append(result, PathPoint{
+			Vertex:       *vertex,
+			WeightUpHere: weights[vertex.ID],
+		})
+	}
+	for x, z := 0, len(result)-1; x < z; x, z = x+1, z-1 {
+		result[x], result[z] = result[z], result[x]
+	}
+	return result
+}
+
+// FindShortestPath returns the path with the smallest sum of weights using Dijkstra's algorithm, the
+// first point is the source and each point has the weight from the source up to its vertex. The weights
+// must not be negative, use BellmanFord for them
+func (g *Graph) FindShortestPath(fromVerticeId string, toVerticeId string) ([]PathPoint, error) {
+	return g.AStar(fromVerticeId, toVerticeId, func(vertex Vertex, target Vertex) int {
+		return 0
+	})
+}
+
+// AStar is FindShortestPath visiting first the vertices with the smallest weight plus the heuristic
+// estimate to the target
+func (g *Graph) AStar(fromVerticeId string, toVerticeId string, heuristic Heuristic) ([]PathPoint, error) {
+	verticeFrom, verticeTo, err := g.pathEnds(fromVerticeId, toVerticeId)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkNonNegativeWeights(); err != nil {
+		return nil, err
+	}
+	if path := search(verticeFrom, verticeTo, heuristic, nil); path != nil {
+		return path, nil
+	}
+	return nil, fmt.Errorf("There is no way from %s to %s", fromVerticeId, toVerticeId)
+}
+
+func (g *Graph) checkNonNegativeWeights() error {
+	for _, vertex := range g.vertexes {
+		for _, edge := range vertex.edgesAdjacentVertices {
+			if edge.Weight < 0 {
+				return fmt.Errorf("The edge from %s to %s has the negative weight %v, use BellmanFord", edge.Tail.ID, edge.Head.ID, edge.Weight)
+			}
+		}
+	}
+	return nil
+}
+
+// search returns the A* path not using the blocked edges or null when the target is unreachable
+func search(verticeFrom *Vertex, verticeTo *Vertex, heuristic Heuristic, blocked func(edge *Edge) bool) []PathPoint {
+	weights := map[string]int{verticeFrom.ID: 0}
+	previous := make(map[string]*Vertex)
+	open := &searchHeap{{vertex: verticeFrom, priority: heuristic(*verticeFrom, *verticeTo)}}
+	for open.Len() > 0 {
+		item := heap.Pop(open).(searchItem)
+		// an item pushed before its vertex got a smaller weight
+		if item.weight > weights[item.vertex.ID] {
+			continue
+		}
+		if item.vertex == verticeTo {
+			return buildPath(verticeTo, previous, weights)
+		}
+		for _, edge := range item.vertex.edgesAdjacentVertices {
+			if blocked != nil && blocked(edge) {
+				continue
+			}
+			weight := item.weight + edge.Weight
+			if current, ok := weights[edge.Head.ID]; ok && current <= weight {
+				continue
+			}
+			weights[edge.Head.ID] = weight
+			previous[edge.Head.ID] = item.vertex
+			heap.Push(open, searchItem{
+				vertex:   edge.Head,
+				weight:   weight,
+				priority: weight + heuristic(*edge.Head, *verticeTo),
+			})
+		}
+	}
+	return nil
+}
+
+// BellmanFord is FindShortestPath accepting negative weights, it returns a NegativeCycleError when the
+// source reaches a negative cycle
+func (g *Graph) BellmanFord(fromVerticeId string, toVerticeId string) ([]PathPoint, error) {
+	verticeFrom, verticeTo, err := g.pathEnds(fromVerticeId, toVerticeId)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(g.vertexes))
+	for id := range g.vertexes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	weights := map[string]int{verticeFrom.ID: 0}
+	previous := make(map[string]*Vertex)
+	relax := func() *Vertex {
+		var relaxed *Vertex
+		for _, id := range ids {
+			weight, reached := weights[id]
+			if !reached {
+				continue
+			}
+			for _, edge := range g.vertexes[id].edgesAdjacentVertices {
+				if current, ok := weights[edge.Head.ID]; !ok || weight+edge.Weight < current {
+					weights[edge.Head.ID] = weight + edge.Weight
+					previous[edge.Head.ID] = edge.Tail
+					relaxed = edge.Head
+				}
+			}
+		}
+		return relaxed
+	}
+
+	// a shortest path has at most one edge less than the number of vertices
+	for i := 1; i < len(ids); i++ {
+		if relax() == nil {
+			return bellmanFordPath(verticeFrom, verticeTo, previous, weights)
+		}
+	}
+	relaxed := relax()
+	if relaxed == nil {
+		return bellmanFordPath(verticeFrom, verticeTo, previous, weights)
+	}
+
+	// after going back once by vertex the walk is inside the cycle
+	for i := 0; i < len(ids); i++ {
+		relaxed = previous[relaxed.ID]
+	}
+	cycle := []string{relaxed.ID}
+	for vertex := previous[relaxed.ID]; vertex != relaxed; vertex = previous[vertex.ID] {
+		cycle = append(cycle, vertex.ID)
+	}
+	cycle = append(cycle, relaxed.ID)
+	for x, z := 0, len(cycle)-1; x < z; x, z = x+1, z-1 {
+		cycle[x], cycle[z] = cycle[z], cycle[x]
+	}
+	return nil, &NegativeCycleError{Cycle: cycle}
+}
+
+func bellmanFordPath(verticeFrom *Vertex, verticeTo *Vertex, previous map[string]*Vertex, weights map[string]int) ([]PathPoint, error) {
+	if _, reached := weights[verticeTo.ID]; !reached {
+		return nil, fmt.Errorf("There is no way from %s to %s", verticeFrom.ID, verticeTo.ID)
+	}
+	return buildPath(verticeTo, previous, weights), nil
+}
@@ -1 +1,215 @@
 package graphstructure
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func buildRoadsGraph() *Graph {
+	graph := NewDirectedCyclicGraph()
+	addTestVertices(graph, "a", "b", "c", "d", "e", "f", "g")
+	graph.AddEdge("a", "b", 4, nil)
+	graph.AddEdge("a", "c", 2, nil)
+	graph.AddEdge("c", "b", 1, nil)
+	graph.AddEdge("b", "d", 5, nil)
+	graph.AddEdge("c", "d", 8, nil)
+	graph.AddEdge("c", "e", 10, nil)
+	graph.AddEdge("d", "e", 2, nil)
+	graph.AddEdge("d", "f", 6, nil)
+	graph.AddEdge("e", "f", 2, nil)
+	graph.AddEdge("f", "a", 1, nil)
+	return graph
+}
+
+func pathDescription(path []PathPoint) string {
+	description := ""
+	for _, point := range path {
+		description += fmt.Sprintf("%s%v ", point.Vertex.ID, point.WeightUpHere)
+	}
+	return description
+}
+
+func TestGraph_ShortestPaths(t *testing.T) {
+	graph := buildRoadsGraph()
+	algorithms := map[string]func(from string, to string) ([]PathPoint, error){
+		"Dijkstra":    graph.FindShortestPath,
+		"BellmanFord": graph.BellmanFord,
+		"AStar": func(from string, to string) ([]PathPoint, error) {
+			return graph.AStar(from, to, func(vertex Vertex, target Vertex) int { return 1 })
+		},
+	}
+	for name, shortestPath := range algorithms {
+		path, err := shortestPath("a", "f")
+		if err != nil {
+			t.Fatalf("%s error finding the path\nError: %s", name, err)
+		}
+		if description := pathDescription(path); description != "a0 c2 b3 d8 e10 f12 " {
+			t.Errorf("%s path must be a0 c2 b3 d8 e10 f12 got %v", name, description)
+		}
+		if path, _ := shortestPath("c", "c"); len(path) != 1 || path[0].Vertex.ID != "c" {
+			t.Errorf("%s path to the source must be only the source got %v", name, pathDescription(path))
+		}
+		if _, err := shortestPath("a", "g"); err == nil || err.Error() != "There is no way from a to g" {
+			t.Errorf("%s path to an unreachable vertex must fail got %v", name, err)
+		}
+		if _, err := shortestPath("a", "z"); err == nil {
+			t.Errorf("%s path to a missing vertex must fail", name)
+		}
+	}
+}
+
+func TestGraph_ShortestPathNegativeWeight(t *testing.T) {
+	graph := NewDirectedAcyclicGraph(false)
+	addTestVertices(graph, "a", "b", "c")
+	graph.AddEdge("a", "b", 4, nil)
+	graph.AddEdge("a", "c", 2, nil)
+	graph.AddEdge("b", "c", -3, nil)
+
+	if _, err := graph.FindShortestPath("a", "c"); err == nil {
+		t.Errorf("Dijkstra must refuse negative weights")
+	}
+	path, err := graph.BellmanFord("a", "c")
+	if err != nil || pathDescription(path) != "a0 b4 c1 " {
+		t.Errorf("Path must be a0 b4 c1 got %v and %v", pathDescription(path), err)
+	}
+}
+
+func TestGraph_BellmanFordNegativeCycle(t *testing.T) {
+	graph := NewDirectedCyclicGraph()
+	addTestVertices(graph, "a", "b", "c", "d")
+	graph.AddEdge("a", "b", 1, nil)
+	graph.AddEdge("b", "c", -3, nil)
+	graph.AddEdge("c", "b", 1, nil)
+	graph.AddEdge("c", "d", 1, nil)
+
+	_, err := graph.BellmanFord("a", "d")
+	var cycleErr *NegativeCycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("Error must be NegativeCycleError got %v", err)
+	}
+	if cycle := fmt.Sprint(cycleErr.Cycle); cycle != "[b c b]" && cycle != "[c b c]" {
+		t.Errorf("Cycle must be b and c got %v", cycle)
+	}
+}
+
+// buildGridGraph links each cell to its four neighbours, moving right or down costs 1 and left or up 3
+func buildGridGraph(size int) *Graph {
+	graph := NewDirectedCyclicGraph()
+	id := func(x int, y int) string {
+		return fmt.Sprintf("%d,%d", x, y)
+	}
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			graph.AddVertice(Vertex{ID: id(x, y), GenericData: [2]int{x, y}})
+		}
+	}
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			if x+1 < size {
+				graph.AddEdge(id(x, y), id(x+1, y), 1, nil)
+				graph.AddEdge(id(x+1, y), id(x, y), 3, nil)
+			}
+			if y+1 < size {
+				graph.AddEdge(id(x, y), id(x, y+1), 1, nil)
+				graph.AddEdge(id(x, y+1), id(x, y), 3, nil)
+			}
+		}
+	}
+	return graph
+}
+
+func manhattanDistance(vertex Vertex, target Vertex) int {
+	from, to := vertex.GenericData.([2]int), target.GenericData.([2]int)
+	distance := 0
+	for i := range from {
+		if from[i] > to[i] {
+			distance += from[i] - to[i]
+		} else {
+			distance += to[i] - from[i]
+		}
+	}
+	return distance
+}
+
+func TestGraph_AStarGrid(t *testing.T) {
+	graph := buildGridGraph(20)
+	for _, ends := range [][2]string{{"0,0", "19,19"}, {"19,19", "0,0"}, {"3,15", "12,2"}} {
+		path, err := graph.AStar(ends[0], ends[1], manhattanDistance)
+		if err != nil {
+			t.Fatalf("Error finding the path from %s to %s\nError: %s", ends[0], ends[1], err)
+		}
+		expected, _ := graph.FindShortestPath(ends[0], ends[1])
+		if weight := path[len(path)-1].WeightUpHere; weight != expected[len(expected)-1].WeightUpHere {
+			t.Errorf("Weight from %s to %s must be %v got %v", ends[0], ends[1], expected[len(expected)-1].WeightUpHere, weight)
+		}
+		if path[0].Vertex.ID != ends[0] || path[len(path)-1].Vertex.ID != ends[1] {
+			t.Errorf("Path must go from %s to %s got %v", ends[0], ends[1], pathDescription(path))
+		}
+	}
+}
+
+// buildRandomGraph has 10k vertices and 100k edges with weights from 1 to 100, every vertex has 10
+// edges to different vertices other than itself
+func buildRandomGraph(tb testing.TB) *Graph {
+	random := rand.New(rand.NewSource(1))
+	graph := NewDirectedCyclicGraph()
+	const vertices = 10000
+	const edgesByVertex = 10
+	for i := 0; i < vertices; i++ {
+		graph.AddVertice(Vertex{ID: fmt.Sprint(i)})
+	}
+	edges := 0
+	for i := 0; i < vertices; i++ {
+		heads := map[int]bool{i: true}
+		for len(heads) <= edgesByVertex {
+			head := random.Intn(vertices)
+			if heads[head] {
+				continue
+			}
+			heads[head] = true
+			if err := graph.AddEdge(fmt.Sprint(i), fmt.Sprint(head), 1+random.Intn(100), nil); err != nil {
+				tb.Fatalf("Error adding the edge from %v to %v\nError: %s", i, head, err)
+			}
+			edges++
+		}
+	}
+	if edges != vertices*edgesByVertex {
+		tb.Fatalf("Edges must be %v got %v", vertices*edgesByVertex, edges)
+	}
+	return graph
+}
+
+func BenchmarkGraph_FindShortestPath(b *testing.B) {
+	graph := buildRandomGraph(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		graph.FindShortestPath("0", fmt.Sprint(9999-i%100))
+	}
+}
+
+func BenchmarkGraph_BellmanFord(b *testing.B) {
+	graph := buildRandomGraph(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		graph.BellmanFord("0", fmt.Sprint(9999-i%100))
+	}
+}
+
+// the grid has 158 x 158 cells and 99k edges
+func BenchmarkGraph_GridFindShortestPath(b *testing.B) {
+	graph := buildGridGraph(158)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		graph.FindShortestPath("0,0", "157,157")
+	}
+}
+
+func BenchmarkGraph_GridAStar(b *testing.B) {
+	graph := buildGridGraph(158)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		graph.AStar("0,0", "157,157", manhattanDistance)
+	}
+}
@@ -0,0 +1,108 @@
+package graphstructure
+
+import (
+	"sort"
+)
+
+// StronglyConnectedComponents returns the groups of vertices that reach each other using Tarjan's
+// algorithm, the IDs of each component are sorted and a component comes before the components it
+// has edges to
+func (g *Graph) StronglyConnectedComponents() [][]string {
+	ids := make([]string, 0, len(g.vertexes))
+	for id := range g.vertexes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	index := 0
+	indexes := make(map[string]int, len(g.vertexes))
+	lowLinks := make(map[string]int, len(g.vertexes))
+	onStack := make(map[string]bool, len(g.vertexes))
+	stack := make([]string, 0)
+	components := make([][]string, 0)
+
+	var connect func(vertex *Vertex)
+	connect = func(vertex *Vertex) {
+		indexes[vertex.ID] = index
+		lowLinks[vertex.ID] = index
+		index++
+		stack = append(stack, vertex.ID)
+		onStack[vertex.ID] = true
+
+		for _, edge := range vertex.edgesAdjacentVertices {
+			if _, visited := indexes[edge.Head.ID]; !visited {
+				connect(edge.Head)
+				lowLinks[vertex.ID] = min(lowLinks[vertex.ID], lowLinks[edge.Head.ID])
+			} else if onStack[edge.Head.ID] {
+				lowLinks[vertex.ID] = min(lowLinks[vertex.ID], indexes[edge.Head.ID])
+			}
+		}
+
+		// the vertex is the root of a component, its members are above it in the stack
+		if lowLinks[vertex.ID] == indexes[vertex.ID] {
+			component := make([]string, 0, 1)
+			for {
+				id := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[id] = false
+				component = append(component, id)
+				if id == vertex.ID {
+					break
+				}
+			}
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+	for _, id := range ids {
+		if _, visited := indexes[id]; !visited {
+			connect(g.vertexes[id])
+		}
+	}
+
+	// Tarjan finds a component after every component it reaches
+	for x, z := 0, len(components)-1; x < z; x, z = x+1, z-1 {
+		components[x], components[z] = components[z], components[x]
+	}
+	return components
+}
+
+// Condensation returns the acyclic graph with one vertex by strongly connected component, the vertex ID
+// is the smallest member ID and its data is the sorted member IDs. There is one edge between two
+// components when any of their members are linked, it has the smallest weight of those edges. The map
+// gives the component vertex ID of each vertex of the graph
+func (g *Graph) Condensation() (*Graph, map[string]string) {
+	components := g.StronglyConnectedComponents()
+	condensation := NewDirectedAcyclicGraph(false)
+	componentOf := make(map[string]string, len(g.vertexes))
+	for _, component := range components {
+		condensation.addVertice(Vertex{
+			ID:                    component[0],
+			GenericData:           component,
+			edgesAdjacentVertices: make([]*Edge, 0),
+		})
+		for _, id := range component {
+			componentOf[id] = component[0]
+		}
+	}
+
+	for _, component := range components {
+		from := condensation.vertexes[component[0]]
+		edges := make(map[string]*Edge)
+		for _, id := range component {
+			for _, edge := range g.vertexes[id].edgesAdjacentVertices {
+				to := componentOf[edge.Head.ID]
+				if to == from.ID {
+					continue
+				}
+				if existing, ok := edges[to]; ok {
+					existing.Weight = min(existing.Weight, edge.Weight)
+					continue
+				}
+				edges[to] = &Edge{Head: condensation.vertexes[to], Tail: from, Weight: edge.Weight}
+				from.edgesAdjacentVertices = append(from.edgesAdjacentVertices, edges[to])
+			}
+		}
+	}
+	return condensation, componentOf
+}
@@ -0,0 +1,69 @@
+package graphstructure
+
+import (
+	"fmt"
+	"testing"
+)
+
+// buildComponentsGraph has the components {a b c} -> {d e} -> {f} and {a b c} -> {f}
+func buildComponentsGraph() *Graph {
+	graph := NewDirectedCyclicGraph()
+	addTestVertices(graph, "a", "b", "c", "d", "e", "f")
+	graph.AddEdge("a", "b", 1, nil)
+	graph.AddEdge("b", "c", 1, nil)
+	graph.AddEdge("c", "a", 1, nil)
+	graph.AddEdge("c", "d", 5, nil)
+	graph.AddEdge("b", "e", 3, nil)
+	graph.AddEdge("d", "e", 1, nil)
+	graph.AddEdge("e", "d", 1, nil)
+	graph.AddEdge("e", "f", 2, nil)
+	graph.AddEdge("a", "f", 9, nil)
+	return graph
+}
+
+func TestGraph_StronglyConnectedComponents(t *testing.T) {
+	components := buildComponentsGraph().StronglyConnectedComponents()
+	if result := fmt.Sprint(components); result != "[[a b c] [d e] [f]]" {
+		t.Errorf("Components must be [[a b c] [d e] [f]] got %v", result)
+	}
+}
+
+func TestGraph_StronglyConnectedComponentsAcyclic(t *testing.T) {
+	graph := NewDirectedAcyclicGraph(false)
+	addTestVertices(graph, "c", "b", "a")
+	graph.AddEdge("c", "b", 0, nil)
+	graph.AddEdge("b", "a", 0, nil)
+
+	if result := fmt.Sprint(graph.StronglyConnectedComponents()); result != "[[c] [b] [a]]" {
+		t.Errorf("Each vertex must be a component in topological order got %v", result)
+	}
+}
+
+func TestGraph_Condensation(t *testing.T) {
+	condensation, componentOf := buildComponentsGraph().Condensation()
+
+	if condensation.ExistsCycle() {
+		t.Errorf("Condensation must be acyclic")
+	}
+	if componentOf["c"] != "a" || componentOf["e"] != "d" || componentOf["f"] != "f" {
+		t.Errorf("Invalid component of the vertices %v", componentOf)
+	}
+	vertex := condensation.vertexes["d"]
+	if vertex == nil || fmt.Sprint(vertex.GenericData) != "[d e]" {
+		t.Fatalf("Vertex d must hold the members [d e] got %v", vertex)
+	}
+
+	weights := make(map[string]int)
+	for _, edge := range condensation.GetEdges() {
+		weights[edge.Tail.ID+edge.Head.ID] = edge.Weight
+	}
+	expected := map[string]int{"ad": 3, "af": 9, "df": 2}
+	if len(weights) != len(expected) {
+		t.Fatalf("Condensation must have the edges %v got %v", expected, weights)
+	}
+	for edge, weight := range expected {
+		if weights[edge] != weight {
+			t.Errorf("Edge %s must have the smallest weight %v got %v", edge, weight, weights[edge])
+		}
+	}
+}
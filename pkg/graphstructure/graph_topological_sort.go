@@ -0,0 +1,79 @@
+package graphstructure
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+)
+
+// idHeap pops the smallest vertex ID first so the orders do not depend on the map iteration
+type idHeap []string
+
+func (h idHeap) Len() int           { return len(h) }
+func (h idHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h idHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *idHeap) Push(id interface{}) {
+	*h = append(*h, id.(string))
+}
+
+func (h *idHeap) Pop() interface{} {
+	old := *h
+	id := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return id
+}
+
+// inDegrees counts the edges arriving at each vertex
+func (g *Graph) inDegrees() map[string]int {
+	inDegree := make(map[string]int, len(g.vertexes))
+	for id, vertex := range g.vertexes {
+		if _, ok := inDegree[id]; !ok {
+			inDegree[id] = 0
+		}
+		for _, edge := range vertex.edgesAdjacentVertices {
+			inDegree[edge.Head.ID]++
+		}
+	}
+	return inDegree
+}
+
+// TopologicalSort returns the vertex IDs ordered so the tail of every edge comes before its head, it uses
+// Kahn's algorithm and among the vertices ready at the same time the smallest ID comes first
+func (g *Graph) TopologicalSort() ([]string, error) {
+	if !g.isDirected {
+		return nil, fmt.Errorf("The topological sort needs a directed graph")
+	}
+	inDegree := g.inDegrees()
+	ready := &idHeap{}
+	for id, degree := range inDegree {
+		if degree == 0 {
+			*ready = append(*ready, id)
+		}
+	}
+	heap.Init(ready)
+
+	order := make([]string, 0, len(g.vertexes))
+	for ready.Len() > 0 {
+		id := heap.Pop(ready).(string)
+		order = append(order, id)
+		for _, edge := range g.vertexes[id].edgesAdjacentVertices {
+			inDegree[edge.Head.ID]--
+			if inDegree[edge.Head.ID] == 0 {
+				heap.Push(ready, edge.Head.ID)
+			}
+		}
+	}
+
+	if len(order) < len(g.vertexes) {
+		remaining := make([]string, 0, len(g.vertexes)-len(order))
+		for id, degree := range inDegree {
+			if degree > 0 {
+				remaining = append(remaining, id)
+			}
+		}
+		sort.Strings(remaining)
+		return nil, fmt.Errorf("The graph has a cycle between the vertices %v", remaining)
+	}
+	return order, nil
+}
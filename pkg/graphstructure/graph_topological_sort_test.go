@@ -0,0 +1,47 @@
+package graphstructure
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGraph_TopologicalSort(t *testing.T) {
+	graph := NewDirectedAcyclicGraph(false)
+	addTestVertices(graph, "e", "d", "c", "b", "a")
+	graph.AddEdge("a", "c", 0, nil)
+	graph.AddEdge("b", "c", 0, nil)
+	graph.AddEdge("c", "d", 0, nil)
+	graph.AddEdge("e", "b", 0, nil)
+
+	for i := 0; i < 10; i++ {
+		order, err := graph.TopologicalSort()
+		if err != nil {
+			t.Fatalf("Error sorting the graph\nError: %s", err)
+		}
+		if fmt.Sprint(order) != "[a e b c d]" {
+			t.Fatalf("Order must be [a e b c d] got %v", order)
+		}
+	}
+}
+
+func TestGraph_TopologicalSortWithCycle(t *testing.T) {
+	graph := NewDirectedCyclicGraph()
+	addTestVertices(graph, "a", "b", "c", "d")
+	graph.AddEdge("a", "b", 0, nil)
+	graph.AddEdge("b", "c", 0, nil)
+	graph.AddEdge("c", "b", 0, nil)
+	graph.AddEdge("c", "d", 0, nil)
+
+	order, err := graph.TopologicalSort()
+	if order != nil || err == nil || err.Error() != "The graph has a cycle between the vertices [b c d]" {
+		t.Errorf("Sort must fail with the vertices left got %v and %v", order, err)
+	}
+}
+
+func TestGraph_TopologicalSortUndirected(t *testing.T) {
+	graph := NewUndirectedGraph()
+	addTestVertices(graph, "a")
+	if _, err := graph.TopologicalSort(); err == nil {
+		t.Errorf("Sort of an undirected graph must fail")
+	}
+}
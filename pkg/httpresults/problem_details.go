@@ -0,0 +1,101 @@
+package httpresults
+
+import (
+	"encoding/json"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/notifications"
+	"net/http"
+)
+
+const (
+	JsonContentType     = "application/json"
+	ProblemContentType  = "application/problem+json"
+	defaultProblemType  = "about:blank"
+	internalErrorDetail = "An unexpected error occurred processing the request"
+)
+
+var statusByResultCode = map[commands.ResultCode]int{
+	commands.SuccessCodeResult:          http.StatusOK,
+	commands.InternalErrorCodeResult:    http.StatusInternalServerError,
+	commands.ValidationFailedCodeResult: http.StatusUnprocessableEntity,
+	commands.NotFoundCodeResult:         http.StatusNotFound,
+	commands.ConflictCodeResult:         http.StatusConflict,
+	commands.UnauthorizedCodeResult:     http.StatusUnauthorized,
+	commands.ForbiddenCodeResult:        http.StatusForbidden,
+}
+
+// ProblemDetails is the RFC 7807 body of the failed results
+type ProblemDetails struct {
+	Type          string                       `json:"type"`
+	Title         string                       `json:"title"`
+	Status        int                          `json:"status"`
+	Detail        string                       `json:"detail,omitempty"`
+	Instance      string                       `json:"instance,omitempty"`
+	Notifications []notifications.Notification `json:"notifications,omitempty"`
+}
+
+// StatusCode maps the result code to the HTTP status, unknown codes are internal errors
+func StatusCode(code commands.ResultCode) int {
+	if status, ok := statusByResultCode[code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// ResultCode returns the code of the result, a success result with notifications is a failed validation
+// and a success result with error is an internal error
+func ResultCode(result commands.CommandResult, notificator notifications.NotificatorInterface) commands.ResultCode {
+	if result.CodeResult != commands.SuccessCodeResult {
+		return result.CodeResult
+	}
+	if result.Error != nil {
+		return commands.InternalErrorCodeResult
+	}
+	if notificator != nil && notificator.HasNotification() {
+		return commands.ValidationFailedCodeResult
+	}
+	return commands.SuccessCodeResult
+}
+
+// NewProblemDetails builds the problem of a failed result, the error message of internal errors is not exposed
+func NewProblemDetails(result commands.CommandResult, notificator notifications.NotificatorInterface, instance string) ProblemDetails {
+	status := StatusCode(ResultCode(result, notificator))
+	problem := ProblemDetails{
+		Type:     defaultProblemType,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Instance: instance,
+	}
+	if status == http.StatusInternalServerError {
+		problem.Detail = internalErrorDetail
+	} else if result.Error != nil {
+		problem.Detail = result.Error.Error()
+	}
+	if notificator != nil && notificator.HasNotification() {
+		problem.Notifications = notificator.GetNotifications()
+	}
+	return problem
+}
+
+// WriteResult writes the ResultData as JSON for success results and the problem details for failed ones
+func WriteResult(w http.ResponseWriter, r *http.Request, result commands.CommandResult, notificator notifications.NotificatorInterface) error {
+	code := ResultCode(result, notificator)
+	if code != commands.SuccessCodeResult {
+		instance := ""
+		if r != nil && r.URL != nil {
+			instance = r.URL.Path
+		}
+		problem := NewProblemDetails(result, notificator, instance)
+		w.Header().Set("Content-Type", ProblemContentType)
+		w.WriteHeader(problem.Status)
+		return json.NewEncoder(w).Encode(problem)
+	}
+
+	if result.ResultData == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+	w.Header().Set("Content-Type", JsonContentType)
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(result.ResultData)
+}
@@ -0,0 +1,91 @@
+package httpresults
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/notifications"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStatusCode(t *testing.T) {
+	expected := map[commands.ResultCode]int{
+		commands.SuccessCodeResult:          http.StatusOK,
+		commands.InternalErrorCodeResult:    http.StatusInternalServerError,
+		commands.ValidationFailedCodeResult: http.StatusUnprocessableEntity,
+		commands.NotFoundCodeResult:         http.StatusNotFound,
+		commands.ConflictCodeResult:         http.StatusConflict,
+		commands.UnauthorizedCodeResult:     http.StatusUnauthorized,
+		commands.ForbiddenCodeResult:        http.StatusForbidden,
+		commands.ResultCode(99):             http.StatusInternalServerError,
+	}
+	for code, status := range expected {
+		if result := StatusCode(code); result != status {
+			t.Errorf("Status of code %v must be %v got %v", code, status, result)
+		}
+	}
+}
+
+func TestWriteResult_Success(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	result := commands.NewCommandResult()
+	result.ResultData = map[string]string{"name": "adriano"}
+
+	WriteResult(recorder, httptest.NewRequest(http.MethodGet, "/users/1", nil), result, notifications.NewNotificator())
+
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != JsonContentType {
+		t.Errorf("Response must be 200 json got %v %v", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+	if body := recorder.Body.String(); body != "{\"name\":\"adriano\"}\n" {
+		t.Errorf("Invalid body %v", body)
+	}
+}
+
+func TestWriteResult_Notifications(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	notificator := notifications.NewNotificator()
+	notificator.AddNotification(notifications.Notification{Message: "name is required", Code: "name"})
+	notificator.AddNotification(notifications.Notification{Message: "email is invalid", Code: "email"})
+
+	WriteResult(recorder, httptest.NewRequest(http.MethodPost, "/users", nil), commands.NewCommandResult(), notificator)
+
+	if recorder.Code != http.StatusUnprocessableEntity || recorder.Header().Get("Content-Type") != ProblemContentType {
+		t.Fatalf("Response must be 422 problem json got %v %v", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+	problem := ProblemDetails{}
+	json.Unmarshal(recorder.Body.Bytes(), &problem)
+	if problem.Status != 422 || problem.Type != "about:blank" || problem.Title != "Unprocessable Entity" || problem.Instance != "/users" {
+		t.Errorf("Invalid problem %v", problem)
+	}
+	if len(problem.Notifications) != 2 || problem.Notifications[1].Code != "email" {
+		t.Errorf("Problem must list the 2 notifications got %v", problem.Notifications)
+	}
+}
+
+func TestWriteResult_InternalErrorIsNotExposed(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	result := commands.NewCommandResult().Fail(commands.InternalErrorCodeResult, errors.New("pq: password authentication failed"))
+
+	WriteResult(recorder, nil, result, nil)
+
+	problem := ProblemDetails{}
+	json.Unmarshal(recorder.Body.Bytes(), &problem)
+	if recorder.Code != http.StatusInternalServerError || problem.Detail != internalErrorDetail {
+		t.Errorf("Internal error must not expose the error got %v %v", recorder.Code, problem.Detail)
+	}
+}
+
+func TestWriteResult_NotFound(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	result := commands.NewCommandResult().Fail(commands.NotFoundCodeResult, errors.New("user 1 not found"))
+
+	WriteResult(recorder, nil, result, nil)
+
+	problem := ProblemDetails{}
+	json.Unmarshal(recorder.Body.Bytes(), &problem)
+	if recorder.Code != http.StatusNotFound || problem.Detail != "user 1 not found" {
+		t.Errorf("Response must be 404 with the error detail got %v %v", recorder.Code, problem.Detail)
+	}
+}
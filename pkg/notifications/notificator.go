@@ -1,8 +1,8 @@
 package notifications
 
 type Notification struct{
-	Message string
-	Code string
+	Message string `json:"message"`
+	Code string `json:"code"`
 }
 
 type NotificatorInterface interface{
@@ -0,0 +1,84 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/queries"
+	"github.com/drprado2/go-backend-framework/pkg/queries/handlers"
+	"reflect"
+	"sync"
+)
+
+type QueryBusInterface interface {
+	Register(queryType reflect.Type, handler handlers.QueryHandlerInterface) error
+	Dispatch(ctx context.Context, query queries.Query) (queries.QueryResult, error)
+}
+
+type HandlerAlreadyRegisteredError struct {
+	QueryType reflect.Type
+}
+
+func (err *HandlerAlreadyRegisteredError) Error() string {
+	return fmt.Sprintf("Already exists one handler registered to the query %v", err.QueryType)
+}
+
+type HandlerNotFoundError struct {
+	QueryType reflect.Type
+}
+
+func (err *HandlerNotFoundError) Error() string {
+	return fmt.Sprintf("There is no handler registered to the query %v", err.QueryType)
+}
+
+type QueryBus struct {
+	executor handlers.QueryHandlerExecutorInterface
+	handlers map[reflect.Type]handlers.QueryHandlerInterface
+	mutex    sync.RWMutex
+}
+
+func NewQueryBus(executor handlers.QueryHandlerExecutorInterface) *QueryBus {
+	return &QueryBus{
+		executor: executor,
+		handlers: make(map[reflect.Type]handlers.QueryHandlerInterface),
+	}
+}
+
+// queryKey makes MyQuery and *MyQuery resolve to the same handler
+func queryKey(queryType reflect.Type) reflect.Type {
+	for queryType.Kind() == reflect.Ptr {
+		queryType = queryType.Elem()
+	}
+	return queryType
+}
+
+func (bus *QueryBus) Register(queryType reflect.Type, handler handlers.QueryHandlerInterface) error {
+	if queryType == nil || handler == nil {
+		return fmt.Errorf("The query type and the handler must not be null")
+	}
+	key := queryKey(queryType)
+
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	if _, exists := bus.handlers[key]; exists {
+		return &HandlerAlreadyRegisteredError{QueryType: key}
+	}
+	bus.handlers[key] = handler
+	return nil
+}
+
+func (bus *QueryBus) Dispatch(ctx context.Context, query queries.Query) (queries.QueryResult, error) {
+	if query == nil {
+		return queries.QueryResult{}, fmt.Errorf("The query must not be null")
+	}
+	key := queryKey(reflect.TypeOf(query))
+
+	bus.mutex.RLock()
+	handler, exists := bus.handlers[key]
+	bus.mutex.RUnlock()
+
+	if !exists {
+		return queries.QueryResult{}, &HandlerNotFoundError{QueryType: key}
+	}
+	return bus.executor.ExecuteQuery(ctx, query, handler), nil
+}
@@ -0,0 +1,66 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"github.com/drprado2/go-backend-framework/pkg/queries"
+	"github.com/drprado2/go-backend-framework/pkg/queries/handlers"
+	"github.com/drprado2/go-backend-framework/pkg/storage"
+	"reflect"
+	"testing"
+)
+
+type getUserQuery struct {
+	queries.QueryBase
+	Name string
+}
+
+type listUsersQuery struct {
+	queries.QueryBase
+}
+
+type executorMock struct{}
+
+func (executor *executorMock) ExecuteQuery(ctx context.Context, query queries.Query, handler handlers.QueryHandlerInterface) queries.QueryResult {
+	return handler.Handle(ctx, query, nil, queries.NewQueryResult())
+}
+
+type getUserHandler struct{}
+
+func (handler *getUserHandler) Handle(ctx context.Context, query queries.Query, db storage.DatabaseInterface, result queries.QueryResult) queries.QueryResult {
+	result.ResultData = query.(*getUserQuery).Name
+	return result
+}
+
+func TestQueryBus_Dispatch(t *testing.T) {
+	bus := NewQueryBus(&executorMock{})
+	if err := bus.Register(reflect.TypeOf(getUserQuery{}), &getUserHandler{}); err != nil {
+		t.Fatalf("Error registering handler\nError: %s", err)
+	}
+
+	result, err := bus.Dispatch(context.Background(), &getUserQuery{Name: "adriano"})
+	if err != nil || result.ResultData != "adriano" {
+		t.Errorf("Result data must be adriano got %v, error %v", result.ResultData, err)
+	}
+}
+
+func TestQueryBus_RegisterDuplicated(t *testing.T) {
+	bus := NewQueryBus(&executorMock{})
+	bus.Register(reflect.TypeOf(getUserQuery{}), &getUserHandler{})
+
+	err := bus.Register(reflect.TypeOf(&getUserQuery{}), &getUserHandler{})
+	var duplicatedErr *HandlerAlreadyRegisteredError
+	if !errors.As(err, &duplicatedErr) {
+		t.Errorf("Error must be HandlerAlreadyRegisteredError got %v", err)
+	}
+}
+
+func TestQueryBus_DispatchWithoutHandler(t *testing.T) {
+	bus := NewQueryBus(&executorMock{})
+
+	_, err := bus.Dispatch(context.Background(), &listUsersQuery{})
+	var notFoundErr *HandlerNotFoundError
+	if !errors.As(err, &notFoundErr) || notFoundErr.QueryType != reflect.TypeOf(listUsersQuery{}) {
+		t.Errorf("Error must be HandlerNotFoundError got %v", err)
+	}
+}
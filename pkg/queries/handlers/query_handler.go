@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/queries"
+	"github.com/drprado2/go-backend-framework/pkg/storage"
+)
+
+type QueryHandlerInterface interface {
+	Handle(ctx context.Context, query queries.Query, db storage.DatabaseInterface, result queries.QueryResult) queries.QueryResult
+}
+
+type QueryStageInterface interface {
+	Execute(ctx context.Context, query queries.Query, result queries.QueryResult) queries.QueryResult
+}
+
+// MandatoryStageInterface is implemented by the before execute stages that must run even after an
+// earlier stage interrupted the pipeline, like the authorization of a cached result
+type MandatoryStageInterface interface {
+	QueryStageInterface
+	Mandatory() bool
+}
+
+type QueryHandlerExecutorInterface interface {
+	ExecuteQuery(ctx context.Context, query queries.Query, handler QueryHandlerInterface) queries.QueryResult
+}
+
+type QueryHandlerExecutor struct {
+	primary              storage.FullDatabaseInterface
+	replica              storage.FullDatabaseInterface
+	beforeExecuteActions []QueryStageInterface
+	afterExecuteActions  []QueryStageInterface
+}
+
+// NewQueryHandlerExecutor runs the handlers in read only transactions of the replica,
+// the primary database is used when the replica is nil
+func NewQueryHandlerExecutor(primary storage.FullDatabaseInterface, replica storage.FullDatabaseInterface) *QueryHandlerExecutor {
+	return &QueryHandlerExecutor{
+		primary:              primary,
+		replica:              replica,
+		beforeExecuteActions: make([]QueryStageInterface, 0),
+		afterExecuteActions:  make([]QueryStageInterface, 0),
+	}
+}
+
+func (executor *QueryHandlerExecutor) AddBeforeExecuteAction(actions ...QueryStageInterface) *QueryHandlerExecutor {
+	executor.beforeExecuteActions = append(executor.beforeExecuteActions, actions...)
+	return executor
+}
+
+func (executor *QueryHandlerExecutor) AddAfterExecuteAction(actions ...QueryStageInterface) *QueryHandlerExecutor {
+	executor.afterExecuteActions = append(executor.afterExecuteActions, actions...)
+	return executor
+}
+
+func (executor *QueryHandlerExecutor) readDatabase() storage.FullDatabaseInterface {
+	if executor.replica != nil {
+		return executor.replica
+	}
+	return executor.primary
+}
+
+// ExecuteQuery runs the before execute actions, the handler and the after execute actions in order,
+// a failed result stops the pipeline and an interrupted one only runs the mandatory before execute actions
+func (executor *QueryHandlerExecutor) ExecuteQuery(ctx context.Context, query queries.Query, handler QueryHandlerInterface) queries.QueryResult {
+	result := queries.NewQueryResult()
+
+	for _, action := range executor.beforeExecuteActions {
+		if mandatory, ok := action.(MandatoryStageInterface); result.Interrupted && (!ok || !mandatory.Mandatory()) {
+			continue
+		}
+		result = runStage(func() queries.QueryResult {
+			return action.Execute(ctx, query, result)
+		}, result)
+		if !result.IsSuccess() {
+			return result
+		}
+	}
+	if result.Interrupted {
+		return result
+	}
+
+	result = runStage(func() queries.QueryResult {
+		return executor.handle(ctx, query, handler, result)
+	}, result)
+	if result.Interrupted || !result.IsSuccess() {
+		return result
+	}
+
+	for _, action := range executor.afterExecuteActions {
+		result = runStage(func() queries.QueryResult {
+			return action.Execute(ctx, query, result)
+		}, result)
+		if result.Interrupted || !result.IsSuccess() {
+			return result
+		}
+	}
+	return result
+}
+
+func (executor *QueryHandlerExecutor) handle(ctx context.Context, query queries.Query, handler QueryHandlerInterface, result queries.QueryResult) queries.QueryResult {
+	tx, err := executor.readDatabase().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return result.Fail(commands.InternalErrorCodeResult, fmt.Errorf("Error beginning read only transaction\nError: %w", err))
+	}
+	defer tx.Rollback()
+
+	return handler.Handle(ctx, query, tx, result)
+}
+
+func runStage(stage func() queries.QueryResult, current queries.QueryResult) (result queries.QueryResult) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result = current.Fail(commands.InternalErrorCodeResult, fmt.Errorf("Panic executing query: %v", recovered))
+		}
+	}()
+	result = stage()
+	if result.ContextData == nil {
+		result.ContextData = current.ContextData
+	}
+	return result
+}
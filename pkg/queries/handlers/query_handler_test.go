@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/drprado2/go-backend-framework/pkg/queries"
+	"github.com/drprado2/go-backend-framework/pkg/storage"
+	"testing"
+)
+
+type databaseSpy struct {
+	name        string
+	begins      int
+	rollbacks   int
+	readOnlyTxs int
+	database    *storage.DatabaseMock
+}
+
+func newDatabaseSpy(name string) *databaseSpy {
+	spy := &databaseSpy{name: name}
+	spy.database = &storage.DatabaseMock{
+		BeginTxMock: func(ctx context.Context, opts *sql.TxOptions) (storage.TransactionInterface, error) {
+			spy.begins++
+			if opts != nil && opts.ReadOnly {
+				spy.readOnlyTxs++
+			}
+			return &storage.TransactionMock{
+				RollbackMock: func() error {
+					spy.rollbacks++
+					return nil
+				},
+			}, nil
+		},
+	}
+	return spy
+}
+
+type queryHandlerMock struct {
+	db storage.DatabaseInterface
+}
+
+func (handler *queryHandlerMock) Handle(ctx context.Context, query queries.Query, db storage.DatabaseInterface, result queries.QueryResult) queries.QueryResult {
+	handler.db = db
+	result.ResultData = "found"
+	return result
+}
+
+type queryStageMock struct {
+	calls    *[]string
+	name     string
+	execMock func(result queries.QueryResult) queries.QueryResult
+}
+
+func (stage *queryStageMock) Execute(ctx context.Context, query queries.Query, result queries.QueryResult) queries.QueryResult {
+	*stage.calls = append(*stage.calls, stage.name)
+	if stage.execMock != nil {
+		return stage.execMock(result)
+	}
+	return result
+}
+
+type mandatoryStageMock struct {
+	queryStageMock
+}
+
+func (stage *mandatoryStageMock) Mandatory() bool {
+	return true
+}
+
+func TestQueryHandlerExecutor_ReadOnlyTransaction(t *testing.T) {
+	primary := newDatabaseSpy("primary")
+	executor := NewQueryHandlerExecutor(primary.database, nil)
+	handler := &queryHandlerMock{}
+
+	result := executor.ExecuteQuery(context.Background(), queries.QueryBase{}, handler)
+
+	if !result.IsSuccess() || result.ResultData != "found" {
+		t.Errorf("Result must be success with data found got %v", result)
+	}
+	if primary.readOnlyTxs != 1 || primary.rollbacks != 1 {
+		t.Errorf("Handler must run in one read only transaction rolled back got %v transactions, %v rollbacks", primary.readOnlyTxs, primary.rollbacks)
+	}
+	if _, ok := handler.db.(*storage.TransactionMock); !ok {
+		t.Errorf("Handler must receive the transaction got %v", handler.db)
+	}
+}
+
+func TestQueryHandlerExecutor_UseReplica(t *testing.T) {
+	primary := newDatabaseSpy("primary")
+	replica := newDatabaseSpy("replica")
+	executor := NewQueryHandlerExecutor(primary.database, replica.database)
+
+	executor.ExecuteQuery(context.Background(), queries.QueryBase{}, &queryHandlerMock{})
+
+	if primary.begins != 0 || replica.begins != 1 {
+		t.Errorf("Query must run on the replica got primary %v, replica %v", primary.begins, replica.begins)
+	}
+}
+
+func TestQueryHandlerExecutor_Stages(t *testing.T) {
+	calls := make([]string, 0)
+	executor := NewQueryHandlerExecutor(newDatabaseSpy("primary").database, nil).
+		AddBeforeExecuteAction(&queryStageMock{calls: &calls, name: "before"}).
+		AddAfterExecuteAction(&queryStageMock{calls: &calls, name: "after"})
+
+	executor.ExecuteQuery(context.Background(), queries.QueryBase{}, &queryHandlerMock{})
+
+	if len(calls) != 2 || calls[0] != "before" || calls[1] != "after" {
+		t.Errorf("Calls must be [before after] got %v", calls)
+	}
+}
+
+func TestQueryHandlerExecutor_BeforeFail(t *testing.T) {
+	calls := make([]string, 0)
+	primary := newDatabaseSpy("primary")
+	executor := NewQueryHandlerExecutor(primary.database, nil).
+		AddBeforeExecuteAction(&queryStageMock{calls: &calls, name: "before", execMock: func(result queries.QueryResult) queries.QueryResult {
+			return result.Fail(1, errors.New("forbidden"))
+		}}).
+		AddAfterExecuteAction(&queryStageMock{calls: &calls, name: "after"})
+
+	result := executor.ExecuteQuery(context.Background(), queries.QueryBase{}, &queryHandlerMock{})
+
+	if result.IsSuccess() || primary.begins != 0 || len(calls) != 1 {
+		t.Errorf("Failed before stage must stop the pipeline got %v, calls %v", result, calls)
+	}
+}
+
+func TestQueryHandlerExecutor_InterruptRunsMandatoryStages(t *testing.T) {
+	calls := make([]string, 0)
+	primary := newDatabaseSpy("primary")
+	executor := NewQueryHandlerExecutor(primary.database, nil).
+		AddBeforeExecuteAction(&queryStageMock{calls: &calls, name: "cache", execMock: func(result queries.QueryResult) queries.QueryResult {
+			result.ResultData = "cached"
+			return result.Interrupt()
+		}}).
+		AddBeforeExecuteAction(&queryStageMock{calls: &calls, name: "optional"}).
+		AddBeforeExecuteAction(&mandatoryStageMock{queryStageMock{calls: &calls, name: "authorization"}}).
+		AddAfterExecuteAction(&queryStageMock{calls: &calls, name: "after"})
+
+	result := executor.ExecuteQuery(context.Background(), queries.QueryBase{}, &queryHandlerMock{})
+
+	if !result.IsSuccess() || result.ResultData != "cached" || primary.begins != 0 {
+		t.Errorf("Interrupted result must be returned without running the handler got %v", result)
+	}
+	if len(calls) != 2 || calls[0] != "cache" || calls[1] != "authorization" {
+		t.Errorf("Calls must be [cache authorization] got %v", calls)
+	}
+}
+
+func TestQueryHandlerExecutor_InterruptedResultFailedByMandatoryStage(t *testing.T) {
+	calls := make([]string, 0)
+	executor := NewQueryHandlerExecutor(newDatabaseSpy("primary").database, nil).
+		AddBeforeExecuteAction(&queryStageMock{calls: &calls, name: "cache", execMock: func(result queries.QueryResult) queries.QueryResult {
+			return result.Interrupt()
+		}}).
+		AddBeforeExecuteAction(&mandatoryStageMock{queryStageMock{calls: &calls, name: "authorization", execMock: func(result queries.QueryResult) queries.QueryResult {
+			return result.Fail(1, errors.New("forbidden"))
+		}}})
+
+	if result := executor.ExecuteQuery(context.Background(), queries.QueryBase{}, &queryHandlerMock{}); result.IsSuccess() {
+		t.Errorf("Mandatory stage failure must fail the interrupted result got %v", result)
+	}
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/google/uuid"
+	"time"
+)
+
+type QueryBase struct {
+	QueryId          uuid.UUID
+	QueryPublishDate time.Time
+}
+
+type Query interface {
+	GetQueryBase() QueryBase
+}
+
+func (query QueryBase) GetQueryBase() QueryBase {
+	return query
+}
+
+// QueryResult shares the result codes and helpers of the commands
+type QueryResult = commands.CommandResult
+
+func NewQueryResult() QueryResult {
+	return commands.NewCommandResult()
+}
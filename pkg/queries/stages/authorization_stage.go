@@ -0,0 +1,45 @@
+package stages
+
+import (
+	"context"
+	"errors"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/queries"
+)
+
+// ErrUnauthenticated must be wrapped by the authorizer errors when the ctx has no credentials
+var ErrUnauthenticated = errors.New("The request has no valid credentials")
+
+type AuthorizerInterface interface {
+	// Authorize returns an error when the query must not run in the ctx, wrapping ErrUnauthenticated
+	// when the credentials are missing
+	Authorize(ctx context.Context, query queries.Query) error
+}
+
+// AuthorizationStage is a before execute stage failing the queries refused by the authorizer as forbidden,
+// or unauthorized when the credentials are missing, it is mandatory so it also runs when a cache lookup
+// registered before it interrupted the pipeline
+type AuthorizationStage struct {
+	authorizer AuthorizerInterface
+}
+
+func NewAuthorizationStage(authorizer AuthorizerInterface) *AuthorizationStage {
+	return &AuthorizationStage{
+		authorizer: authorizer,
+	}
+}
+
+func (stage *AuthorizationStage) Mandatory() bool {
+	return true
+}
+
+func (stage *AuthorizationStage) Execute(ctx context.Context, query queries.Query, result queries.QueryResult) queries.QueryResult {
+	if err := stage.authorizer.Authorize(ctx, query); err != nil {
+		result.ResultData = nil
+		if errors.Is(err, ErrUnauthenticated) {
+			return result.Fail(commands.UnauthorizedCodeResult, err)
+		}
+		return result.Fail(commands.ForbiddenCodeResult, err)
+	}
+	return result
+}
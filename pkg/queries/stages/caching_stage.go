@@ -0,0 +1,153 @@
+package stages
+
+import (
+	"context"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/queries"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	CacheHitContextKey = "cacheHit"
+)
+
+// CacheableQuery must be implemented by the queries that can be cached, queries with the
+// same type and CacheKey share the cached result
+type CacheableQuery interface {
+	queries.Query
+	CacheKey() string
+}
+
+type QueryCacheInterface interface {
+	Get(key string) (queries.QueryResult, bool)
+	Set(key string, result queries.QueryResult, duration time.Duration)
+}
+
+// CacheLookupStage is a before execute stage interrupting the pipeline with the cached result
+type CacheLookupStage struct {
+	cache QueryCacheInterface
+}
+
+// CacheStoreStage is an after execute stage caching the success results
+type CacheStoreStage struct {
+	cache    QueryCacheInterface
+	duration time.Duration
+}
+
+func NewCachingStages(cache QueryCacheInterface, duration time.Duration) (*CacheLookupStage, *CacheStoreStage) {
+	return &CacheLookupStage{cache: cache}, &CacheStoreStage{cache: cache, duration: duration}
+}
+
+func cacheKey(query queries.Query) (string, bool) {
+	cacheable, ok := query.(CacheableQuery)
+	if !ok {
+		return "", false
+	}
+	queryType := reflect.TypeOf(query)
+	for queryType.Kind() == reflect.Ptr {
+		queryType = queryType.Elem()
+	}
+	return queryType.PkgPath() + "." + queryType.Name() + ":" + cacheable.CacheKey(), true
+}
+
+func (stage *CacheLookupStage) Execute(ctx context.Context, query queries.Query, result queries.QueryResult) queries.QueryResult {
+	key, cacheable := cacheKey(query)
+	if !cacheable {
+		return result
+	}
+	cached, found := stage.cache.Get(key)
+	if !found {
+		return result
+	}
+	cached.ContextData = map[string]interface{}{
+		CacheHitContextKey: true,
+	}
+	return cached.Interrupt()
+}
+
+func (stage *CacheStoreStage) Execute(ctx context.Context, query queries.Query, result queries.QueryResult) queries.QueryResult {
+	if key, cacheable := cacheKey(query); cacheable && result.IsSuccess() {
+		stage.cache.Set(key, result, stage.duration)
+	}
+	return result
+}
+
+type memoryCacheItem struct {
+	result    queries.QueryResult
+	expiresAt time.Time
+}
+
+// MemoryQueryCache keeps at most maxItems results, when it is full the expired results are removed
+// and then the result closest to expire
+type MemoryQueryCache struct {
+	items    map[string]memoryCacheItem
+	maxItems int
+	mutex    sync.RWMutex
+}
+
+func NewMemoryQueryCache(maxItems int) (*MemoryQueryCache, error) {
+	if maxItems < 1 {
+		return nil, fmt.Errorf("The max items of the cache must be greater than 0")
+	}
+	return &MemoryQueryCache{
+		items:    make(map[string]memoryCacheItem),
+		maxItems: maxItems,
+	}, nil
+}
+
+func (cache *MemoryQueryCache) Get(key string) (queries.QueryResult, bool) {
+	cache.mutex.RLock()
+	item, ok := cache.items[key]
+	cache.mutex.RUnlock()
+	if !ok {
+		return queries.QueryResult{}, false
+	}
+	if time.Now().After(item.expiresAt) {
+		cache.mutex.Lock()
+		if current, ok := cache.items[key]; ok && current.expiresAt == item.expiresAt {
+			delete(cache.items, key)
+		}
+		cache.mutex.Unlock()
+		return queries.QueryResult{}, false
+	}
+	return item.result, true
+}
+
+func (cache *MemoryQueryCache) Set(key string, result queries.QueryResult, duration time.Duration) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if _, exists := cache.items[key]; !exists && len(cache.items) >= cache.maxItems {
+		cache.evict()
+	}
+	cache.items[key] = memoryCacheItem{
+		result:    result,
+		expiresAt: time.Now().Add(duration),
+	}
+}
+
+// Len returns the number of results kept, including the expired ones not removed yet
+func (cache *MemoryQueryCache) Len() int {
+	cache.mutex.RLock()
+	defer cache.mutex.RUnlock()
+	return len(cache.items)
+}
+
+func (cache *MemoryQueryCache) evict() {
+	now := time.Now()
+	closestKey := ""
+	var closestExpiration time.Time
+	for key, item := range cache.items {
+		if now.After(item.expiresAt) {
+			delete(cache.items, key)
+			continue
+		}
+		if closestKey == "" || item.expiresAt.Before(closestExpiration) {
+			closestKey, closestExpiration = key, item.expiresAt
+		}
+	}
+	if len(cache.items) >= cache.maxItems {
+		delete(cache.items, closestKey)
+	}
+}
@@ -0,0 +1,146 @@
+package stages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/commands"
+	"github.com/drprado2/go-backend-framework/pkg/queries"
+	"github.com/drprado2/go-backend-framework/pkg/queries/handlers"
+	"github.com/drprado2/go-backend-framework/pkg/storage"
+	"testing"
+	"time"
+)
+
+type getProductQuery struct {
+	queries.QueryBase
+	ProductId string
+}
+
+func (query *getProductQuery) CacheKey() string {
+	return query.ProductId
+}
+
+type authorizerMock struct {
+	err error
+}
+
+func (authorizer *authorizerMock) Authorize(ctx context.Context, query queries.Query) error {
+	return authorizer.err
+}
+
+func newMemoryQueryCache(t *testing.T, maxItems int) *MemoryQueryCache {
+	cache, err := NewMemoryQueryCache(maxItems)
+	if err != nil {
+		t.Fatalf("Error creating cache\nError: %s", err)
+	}
+	return cache
+}
+
+func TestCachingStages(t *testing.T) {
+	lookup, store := NewCachingStages(newMemoryQueryCache(t, 100), time.Minute)
+	query := &getProductQuery{ProductId: "1"}
+
+	if result := lookup.Execute(context.Background(), query, queries.NewQueryResult()); result.Interrupted {
+		t.Fatalf("Lookup without cached result must not interrupt")
+	}
+
+	handled := queries.NewQueryResult()
+	handled.ResultData = "product 1"
+	store.Execute(context.Background(), query, handled)
+
+	result := lookup.Execute(context.Background(), &getProductQuery{ProductId: "1"}, queries.NewQueryResult())
+	if !result.Interrupted || result.ResultData != "product 1" || result.ContextData[CacheHitContextKey] != true {
+		t.Errorf("Lookup must interrupt with the cached result got %v", result)
+	}
+	if result := lookup.Execute(context.Background(), &getProductQuery{ProductId: "2"}, queries.NewQueryResult()); result.Interrupted {
+		t.Errorf("Lookup of other key must not interrupt")
+	}
+}
+
+func TestCachingStages_Expiration(t *testing.T) {
+	lookup, store := NewCachingStages(newMemoryQueryCache(t, 100), time.Millisecond)
+	query := &getProductQuery{ProductId: "1"}
+
+	store.Execute(context.Background(), query, queries.NewQueryResult())
+	time.Sleep(5 * time.Millisecond)
+
+	if result := lookup.Execute(context.Background(), query, queries.NewQueryResult()); result.Interrupted {
+		t.Errorf("Expired result must not be returned")
+	}
+}
+
+func TestCachingStages_AuthorizeCachedResult(t *testing.T) {
+	cache := newMemoryQueryCache(t, 100)
+	lookup, store := NewCachingStages(cache, time.Minute)
+	cached := queries.NewQueryResult()
+	cached.ResultData = "product 1"
+	store.Execute(context.Background(), &getProductQuery{ProductId: "1"}, cached)
+
+	authorizer := &authorizerMock{err: errors.New("forbidden")}
+	executor := handlers.NewQueryHandlerExecutor(&storage.DatabaseMock{}, nil).
+		AddBeforeExecuteAction(lookup, NewAuthorizationStage(authorizer))
+
+	result := executor.ExecuteQuery(context.Background(), &getProductQuery{ProductId: "1"}, nil)
+	if result.IsSuccess() || result.ResultData != nil {
+		t.Errorf("Cached result must not be returned to a refused caller got %v", result)
+	}
+
+	authorizer.err = nil
+	result = executor.ExecuteQuery(context.Background(), &getProductQuery{ProductId: "1"}, nil)
+	if !result.IsSuccess() || result.ResultData != "product 1" {
+		t.Errorf("Cached result must be returned to an authorized caller got %v", result)
+	}
+}
+
+func TestMemoryQueryCache_RemoveExpired(t *testing.T) {
+	cache := newMemoryQueryCache(t, 100)
+	cache.Set("expired", queries.NewQueryResult(), -time.Second)
+
+	if _, found := cache.Get("expired"); found {
+		t.Errorf("Expired result must not be found")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Expired result must be removed on get got %v items", cache.Len())
+	}
+}
+
+func TestMemoryQueryCache_MaxItems(t *testing.T) {
+	cache := newMemoryQueryCache(t, 2)
+	cache.Set("expired", queries.NewQueryResult(), -time.Second)
+	cache.Set("first", queries.NewQueryResult(), time.Minute)
+	cache.Set("second", queries.NewQueryResult(), 2*time.Minute)
+	cache.Set("third", queries.NewQueryResult(), 3*time.Minute)
+
+	if cache.Len() != 2 {
+		t.Errorf("Cache items must be 2 got %v", cache.Len())
+	}
+	if _, found := cache.Get("first"); found {
+		t.Errorf("Result closest to expire must be evicted")
+	}
+	for _, key := range []string{"second", "third"} {
+		if _, found := cache.Get(key); !found {
+			t.Errorf("Result %v must be kept", key)
+		}
+	}
+	if _, err := NewMemoryQueryCache(0); err == nil {
+		t.Errorf("Cache without items must not be created")
+	}
+}
+
+func TestAuthorizationStage(t *testing.T) {
+	allowed := NewAuthorizationStage(&authorizerMock{})
+	if result := allowed.Execute(context.Background(), &getProductQuery{}, queries.NewQueryResult()); !result.IsSuccess() {
+		t.Errorf("Authorized query must succeed got %v", result)
+	}
+
+	denied := NewAuthorizationStage(&authorizerMock{err: errors.New("forbidden")})
+	if result := denied.Execute(context.Background(), &getProductQuery{}, queries.NewQueryResult()); result.CodeResult != commands.ForbiddenCodeResult {
+		t.Errorf("Refused query must fail as forbidden got %v", result)
+	}
+
+	unauthenticated := NewAuthorizationStage(&authorizerMock{err: fmt.Errorf("Missing token\nError: %w", ErrUnauthenticated)})
+	if result := unauthenticated.Execute(context.Background(), &getProductQuery{}, queries.NewQueryResult()); result.CodeResult != commands.UnauthorizedCodeResult {
+		t.Errorf("Query without credentials must fail as unauthorized got %v", result)
+	}
+}
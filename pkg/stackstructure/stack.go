@@ -25,10 +25,8 @@ func (s *Stack) Unstack() interface{} {
 }
 
 func (s *Stack) CopyToSlice() []interface{} {
-	newSlice := make([]interface{}, 0, len(s.elements))
-	for i, elem := range s.elements {
-		newSlice[i] = elem
-	}
+	newSlice := make([]interface{}, len(s.elements))
+	copy(newSlice, s.elements)
 	return newSlice
 }
 
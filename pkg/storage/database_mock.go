@@ -12,10 +12,10 @@ type DatabaseMock struct {
 	PrepareMock func(string) (*sql.Stmt, error)
 	QueryMock func(string, ...interface{}) (*sql.Rows, error)
 	QueryRowMock func(string, ...interface{}) *sql.Row
-	BeginMock func() (*TransactionInterface, error)
-	BeginTxMock func(context.Context, *sql.TxOptions) (*TransactionInterface, error)
+	BeginMock func() (TransactionInterface, error)
+	BeginTxMock func(context.Context, *sql.TxOptions) (TransactionInterface, error)
 	CloseMock func() error
-	ConnMock func(ctx context.Context) (*ConnectionInterface, error)
+	ConnMock func(ctx context.Context) (ConnectionInterface, error)
 	DriverMock func() driver.Driver
 	ExecContextMock func(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
 	PingMock func() error
@@ -46,11 +46,11 @@ func (mock *DatabaseMock) QueryRow(query string, args ...interface{}) *sql.Row{
 	return mock.QueryRowMock(query, args)
 }
 
-func (mock *DatabaseMock) Begin() (*TransactionInterface, error){
+func (mock *DatabaseMock) Begin() (TransactionInterface, error){
 	return mock.BeginMock()
 }
 
-func (mock *DatabaseMock) BeginTx(ctx context.Context, opts *sql.TxOptions) (*TransactionInterface, error){
+func (mock *DatabaseMock) BeginTx(ctx context.Context, opts *sql.TxOptions) (TransactionInterface, error){
 	return mock.BeginTxMock(ctx, opts)
 }
 
@@ -58,7 +58,7 @@ func (mock *DatabaseMock) Close() error{
 	return mock.CloseMock()
 }
 
-func (mock *DatabaseMock) Conn(ctx context.Context) (*ConnectionInterface, error){
+func (mock *DatabaseMock) Conn(ctx context.Context) (ConnectionInterface, error){
 	return mock.ConnMock(ctx)
 }
 
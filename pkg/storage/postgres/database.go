@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"github.com/drprado2/go-backend-framework/pkg/configs"
 	"github.com/drprado2/go-backend-framework/pkg/storage"
 	_ "github.com/lib/pq"
 )
@@ -71,6 +72,11 @@ func NewDatabaseFactory(connString string) *DatabaseFactory {
 	}
 }
 
+// NewDatabaseFactoryFromOptions receives the database options injected by the service provider
+func NewDatabaseFactoryFromOptions(options *configs.DatabaseOptions) *DatabaseFactory {
+	return NewDatabaseFactory(options.ConnectionString())
+}
+
 func (factory *DatabaseFactory) GetDB() (storage.FullDatabaseInterface, error) {
 	db, err := sql.Open(postgres, factory.connectionString)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/drprado2/go-backend-framework/pkg/configs"
+	"github.com/drprado2/go-backend-framework/pkg/dependencyinjection"
 	"github.com/drprado2/go-backend-framework/pkg/storage"
 	"github.com/drprado2/go-backend-framework/pkg/tests/testutilities"
 	"github.com/google/uuid"
@@ -227,6 +228,36 @@ func TestDatabaseFactory(t *testing.T) {
 	}
 }
 
+func TestDatabaseFactory_OverriddenOptions(t *testing.T) {
+	builder := dependencyinjection.NewServiceBuilder()
+	builder.AddSingletonConstructor(func() *configs.Configuration {
+		return &configs.Configuration{DatabaseHost: "db", DatabasePort: 5432}
+	}, nil)
+	builder.AddOptions(configs.DatabaseSection, &configs.DatabaseOptions{})
+	builder.AddSingletonConstructor(NewDatabaseFactoryFromOptions, nil)
+	provider, err := builder.BuildServiceProvider()
+	if err != nil {
+		t.Fatalf("Error building provider\nError: %s", err)
+	}
+	scope, _ := provider.CreateScope()
+	defer scope.Dispose()
+
+	testOptions := &configs.DatabaseOptions{Host: "test-db", Port: 5433, Name: "orders_test"}
+	if err := dependencyinjection.OverrideService(scope, testOptions); err != nil {
+		t.Fatalf("Error overriding options\nError: %s", err)
+	}
+
+	var factory, rootFactory *DatabaseFactory
+	dependencyinjection.Resolve(scope, &factory)
+	dependencyinjection.Resolve(provider, &rootFactory)
+	if factory.connectionString != testOptions.ConnectionString() {
+		t.Errorf("Factory must receive the overridden options got %v", factory.connectionString)
+	}
+	if rootFactory.connectionString != (&configs.DatabaseOptions{Host: "db", Port: 5432}).ConnectionString() {
+		t.Errorf("Root factory must keep the registered options got %v", rootFactory.connectionString)
+	}
+}
+
 func TestTransactionWithConnection(t *testing.T) {
 	fixture := databaseFixture{}
 	fixture.setup(t)
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+)
+
+type TransactionMock struct {
+	CommitMock          func() error
+	ExecMock            func(string, ...interface{}) (sql.Result, error)
+	ExecContextMock     func(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	PrepareMock         func(string) (*sql.Stmt, error)
+	PrepareContextMock  func(ctx context.Context, query string) (*sql.Stmt, error)
+	QueryMock           func(string, ...interface{}) (*sql.Rows, error)
+	QueryContextMock    func(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowMock        func(string, ...interface{}) *sql.Row
+	QueryRowContextMock func(ctx context.Context, query string, args ...interface{}) *sql.Row
+	RollbackMock        func() error
+	StmtMock            func(stmt *sql.Stmt) *sql.Stmt
+	StmtContextMock     func(ctx context.Context, stmt *sql.Stmt) *sql.Stmt
+}
+
+func (mock *TransactionMock) Commit() error {
+	return mock.CommitMock()
+}
+
+func (mock *TransactionMock) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return mock.ExecMock(query, args...)
+}
+
+func (mock *TransactionMock) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return mock.ExecContextMock(ctx, query, args...)
+}
+
+func (mock *TransactionMock) Prepare(query string) (*sql.Stmt, error) {
+	return mock.PrepareMock(query)
+}
+
+func (mock *TransactionMock) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return mock.PrepareContextMock(ctx, query)
+}
+
+func (mock *TransactionMock) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return mock.QueryMock(query, args...)
+}
+
+func (mock *TransactionMock) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return mock.QueryContextMock(ctx, query, args...)
+}
+
+func (mock *TransactionMock) QueryRow(query string, args ...interface{}) *sql.Row {
+	return mock.QueryRowMock(query, args...)
+}
+
+func (mock *TransactionMock) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return mock.QueryRowContextMock(ctx, query, args...)
+}
+
+func (mock *TransactionMock) Rollback() error {
+	return mock.RollbackMock()
+}
+
+func (mock *TransactionMock) Stmt(stmt *sql.Stmt) *sql.Stmt {
+	return mock.StmtMock(stmt)
+}
+
+func (mock *TransactionMock) StmtContext(ctx context.Context, stmt *sql.Stmt) *sql.Stmt {
+	return mock.StmtContextMock(ctx, stmt)
+}
@@ -4,7 +4,7 @@ type UnitOfWorkMock struct {
 	BeginTranMock func() error
 	RollbackMock func() error
 	CommitMock func() error
-	GetDatabaseMock func() *DatabaseInterface
+	GetDatabaseMock func() DatabaseInterface
 }
 
 func (mock *UnitOfWorkMock) BeginTran() error{
@@ -19,7 +19,7 @@ func (mock *UnitOfWorkMock) Commit() error{
 	return mock.CommitMock()
 }
 
-func (mock *UnitOfWorkMock) GetDatabase() *DatabaseInterface{
+func (mock *UnitOfWorkMock) GetDatabase() DatabaseInterface{
 	return mock.GetDatabaseMock()
 }
 
@@ -0,0 +1,22 @@
+package testutilities
+
+import (
+	"github.com/drprado2/go-backend-framework/pkg/dependencyinjection"
+	"testing"
+)
+
+// AssertServiceRegistrations fails the test when a registration depends on a missing service or when it
+// isn`t used by the roots nor by the hosted services, the roots are the service keys the application resolves
+func AssertServiceRegistrations(t testing.TB, builder *dependencyinjection.ServiceBuilder, roots ...string) {
+	t.Helper()
+	diagram, err := builder.Diagram()
+	if err != nil {
+		t.Fatalf("Error building the service diagram\nError: %s", err)
+	}
+	if unresolvable := diagram.Unresolvable(); len(unresolvable) > 0 {
+		t.Errorf("Services depending on missing registrations: %v", unresolvable)
+	}
+	if unused := diagram.Unused(roots...); len(unused) > 0 {
+		t.Errorf("Services not used by the roots nor by the hosted services: %v", unused)
+	}
+}
@@ -0,0 +1,38 @@
+package testutilities
+
+import (
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/configs"
+	"testing"
+)
+
+// TestDatabase is a database with a random name created for one test and dropped when the test ends
+type TestDatabase struct {
+	Name             string
+	ConnectionString string
+}
+
+func NewTestDatabase(t testing.TB) *TestDatabase {
+	t.Helper()
+	connWithoutDB, connWithDB, dbName, err := CreateRandomDB()
+	if err != nil {
+		t.Fatal("Error in setup", err)
+	}
+	connWithDB.Close()
+	t.Cleanup(func() {
+		defer connWithoutDB.Close()
+		if _, err := connWithoutDB.Exec(`drop database "` + dbName + `" WITH (FORCE);`); err != nil {
+			t.Error("Error on teardown", err)
+		}
+	})
+
+	config, err := configs.GetConfig()
+	if err != nil {
+		t.Fatal("Error in setup", err)
+	}
+	return &TestDatabase{
+		Name: dbName,
+		ConnectionString: fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+			config.DatabaseHost, config.DatabasePort, config.DatabaseUser, config.DatabasePassword, dbName),
+	}
+}
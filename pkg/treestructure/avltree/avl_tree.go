@@ -1 +1,340 @@
 package avltree
+
+import (
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/treestructure"
+)
+
+type OrderedNode[K any] struct {
+	Left   *OrderedNode[K]
+	Right  *OrderedNode[K]
+	ID     K
+	Data   interface{}
+	height int
+	size   int
+}
+
+type Node = OrderedNode[int]
+
+// Height is 1 for a leaf and 0 for a null node
+func (n *OrderedNode[K]) Height() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *OrderedNode[K]) balanceFactor() int {
+	return n.Left.Height() - n.Right.Height()
+}
+
+// Size is the number of nodes of the subtree rooted by the node
+func (n *OrderedNode[K]) Size() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *OrderedNode[K]) update() {
+	n.height = 1 + max(n.Left.Height(), n.Right.Height())
+	n.size = 1 + n.Left.Size() + n.Right.Size()
+}
+
+// OrderedAVLTree keeps the height of the subtrees of every node differing at most by one, the nodes
+// are ordered by ID using the comparator
+type OrderedAVLTree[K any] struct {
+	Root    *OrderedNode[K]
+	compare treestructure.Comparator[K]
+}
+
+// AVLTree is the int keyed tree
+type AVLTree struct {
+	*OrderedAVLTree[int]
+}
+
+func NewAVLTree() *AVLTree {
+	return &AVLTree{NewOrderedAVLTree(treestructure.CompareOrdered[int])}
+}
+
+func NewOrderedAVLTree[K any](compare treestructure.Comparator[K]) *OrderedAVLTree[K] {
+	return &OrderedAVLTree[K]{
+		compare: compare,
+	}
+}
+
+func (t *OrderedAVLTree[K]) Add(node *OrderedNode[K]) error {
+	if node == nil {
+		return fmt.Errorf("The node must not be null")
+	}
+	if t.Find(node.ID) != nil {
+		return fmt.Errorf("The element %v already exists in the tree", node.ID)
+	}
+	node.Left, node.Right, node.height, node.size = nil, nil, 1, 1
+	t.Root = t.add(t.Root, node)
+	return nil
+}
+
+func (t *OrderedAVLTree[K]) add(currentNode *OrderedNode[K], node *OrderedNode[K]) *OrderedNode[K] {
+	if currentNode == nil {
+		return node
+	}
+	if t.compare(currentNode.ID, node.ID) < 0 {
+		currentNode.Right = t.add(currentNode.Right, node)
+	} else {
+		currentNode.Left = t.add(currentNode.Left, node)
+	}
+	return rebalance(currentNode)
+}
+
+// Delete removes the node with the ID, the removed node is returned unlinked from the tree
+func (t *OrderedAVLTree[K]) Delete(nodeId K) (*OrderedNode[K], bool) {
+	root, deleted := t.remove(t.Root, nodeId)
+	if deleted == nil {
+		return nil, false
+	}
+	t.Root = root
+	deleted.Left, deleted.Right, deleted.height, deleted.size = nil, nil, 1, 1
+	return deleted, true
+}
+
+func (t *OrderedAVLTree[K]) remove(currentNode *OrderedNode[K], nodeId K) (*OrderedNode[K], *OrderedNode[K]) {
+	if currentNode == nil {
+		return nil, nil
+	}
+	var deleted *OrderedNode[K]
+	if comparison := t.compare(currentNode.ID, nodeId); comparison < 0 {
+		currentNode.Right, deleted = t.remove(currentNode.Right, nodeId)
+	} else if comparison > 0 {
+		currentNode.Left, deleted = t.remove(currentNode.Left, nodeId)
+	} else {
+		deleted = currentNode
+		if currentNode.Left == nil {
+			return currentNode.Right, deleted
+		}
+		if currentNode.Right == nil {
+			return currentNode.Left, deleted
+		}
+		// the successor takes the place of the deleted node
+		right, successor := removeMin(currentNode.Right)
+		successor.Left = currentNode.Left
+		successor.Right = right
+		currentNode = successor
+	}
+	if deleted == nil {
+		return currentNode, nil
+	}
+	return rebalance(currentNode), deleted
+}
+
+func removeMin[K any](currentNode *OrderedNode[K]) (*OrderedNode[K], *OrderedNode[K]) {
+	if currentNode.Left == nil {
+		return currentNode.Right, currentNode
+	}
+	var successor *OrderedNode[K]
+	currentNode.Left, successor = removeMin(currentNode.Left)
+	return rebalance(currentNode), successor
+}
+
+func rotateRight[K any](node *OrderedNode[K]) *OrderedNode[K] {
+	newRoot := node.Left
+	node.Left = newRoot.Right
+	newRoot.Right = node
+	node.update()
+	newRoot.update()
+	return newRoot
+}
+
+func rotateLeft[K any](node *OrderedNode[K]) *OrderedNode[K] {
+	newRoot := node.Right
+	node.Right = newRoot.Left
+	newRoot.Left = node
+	node.update()
+	newRoot.update()
+	return newRoot
+}
+
+func rebalance[K any](node *OrderedNode[K]) *OrderedNode[K] {
+	node.update()
+	switch balance := node.balanceFactor(); {
+	case balance > 1:
+		if node.Left.balanceFactor() < 0 {
+			node.Left = rotateLeft(node.Left)
+		}
+		return rotateRight(node)
+	case balance < -1:
+		if node.Right.balanceFactor() > 0 {
+			node.Right = rotateRight(node.Right)
+		}
+		return rotateLeft(node)
+	}
+	return node
+}
+
+func (t *OrderedAVLTree[K]) Find(nodeId K) *OrderedNode[K] {
+	currentNode := t.Root
+	for currentNode != nil {
+		if comparison := t.compare(currentNode.ID, nodeId); comparison < 0 {
+			currentNode = currentNode.Right
+		} else if comparison > 0 {
+			currentNode = currentNode.Left
+		} else {
+			return currentNode
+		}
+	}
+	return nil
+}
+
+func (t *OrderedAVLTree[K]) Min() *OrderedNode[K] {
+	if t.Root == nil {
+		return nil
+	}
+	currentNode := t.Root
+	for currentNode.Left != nil {
+		currentNode = currentNode.Left
+	}
+	return currentNode
+}
+
+func (t *OrderedAVLTree[K]) Max() *OrderedNode[K] {
+	if t.Root == nil {
+		return nil
+	}
+	currentNode := t.Root
+	for currentNode.Right != nil {
+		currentNode = currentNode.Right
+	}
+	return currentNode
+}
+
+// Floor returns the node with the greatest ID less than or equal to the nodeId
+func (t *OrderedAVLTree[K]) Floor(nodeId K) *OrderedNode[K] {
+	var floor *OrderedNode[K]
+	currentNode := t.Root
+	for currentNode != nil {
+		comparison := t.compare(currentNode.ID, nodeId)
+		if comparison == 0 {
+			return currentNode
+		}
+		if comparison < 0 {
+			floor = currentNode
+			currentNode = currentNode.Right
+		} else {
+			currentNode = currentNode.Left
+		}
+	}
+	return floor
+}
+
+// Ceiling returns the node with the smallest ID greater than or equal to the nodeId
+func (t *OrderedAVLTree[K]) Ceiling(nodeId K) *OrderedNode[K] {
+	var ceiling *OrderedNode[K]
+	currentNode := t.Root
+	for currentNode != nil {
+		comparison := t.compare(currentNode.ID, nodeId)
+		if comparison == 0 {
+			return currentNode
+		}
+		if comparison > 0 {
+			ceiling = currentNode
+			currentNode = currentNode.Left
+		} else {
+			currentNode = currentNode.Right
+		}
+	}
+	return ceiling
+}
+
+// InOrder visits the nodes by ascending ID until visit returns false
+func (t *OrderedAVLTree[K]) InOrder(visit func(node *OrderedNode[K]) bool) {
+	inOrder(t.Root, visit)
+}
+
+func inOrder[K any](currentNode *OrderedNode[K], visit func(node *OrderedNode[K]) bool) bool {
+	if currentNode == nil {
+		return true
+	}
+	return inOrder(currentNode.Left, visit) && visit(currentNode) && inOrder(currentNode.Right, visit)
+}
+
+// RangeQuery returns the nodes with ID between lo and hi inclusive in ascending order
+func (t *OrderedAVLTree[K]) RangeQuery(lo K, hi K) []*OrderedNode[K] {
+	result := make([]*OrderedNode[K], 0)
+	var query func(currentNode *OrderedNode[K])
+	query = func(currentNode *OrderedNode[K]) {
+		if currentNode == nil {
+			return
+		}
+		aboveLo := t.compare(currentNode.ID, lo) >= 0
+		belowHi := t.compare(currentNode.ID, hi) <= 0
+		if aboveLo {
+			query(currentNode.Left)
+		}
+		if aboveLo && belowHi {
+			result = append(result, currentNode)
+		}
+		if belowHi {
+			query(currentNode.Right)
+		}
+	}
+	query(t.Root)
+	return result
+}
+
+// Kth returns the node at the zero based position k of the ascending order, null when k is out of range
+func (t *OrderedAVLTree[K]) Kth(k int) *OrderedNode[K] {
+	currentNode := t.Root
+	for currentNode != nil {
+		leftSize := currentNode.Left.Size()
+		if k < leftSize {
+			currentNode = currentNode.Left
+		} else if k > leftSize {
+			k -= leftSize + 1
+			currentNode = currentNode.Right
+		} else {
+			return currentNode
+		}
+	}
+	return nil
+}
+
+// Rank returns how many nodes have ID less than the nodeId
+func (t *OrderedAVLTree[K]) Rank(nodeId K) int {
+	rank := 0
+	currentNode := t.Root
+	for currentNode != nil {
+		comparison := t.compare(currentNode.ID, nodeId)
+		if comparison < 0 {
+			rank += currentNode.Left.Size() + 1
+			currentNode = currentNode.Right
+		} else if comparison > 0 {
+			currentNode = currentNode.Left
+		} else {
+			return rank + currentNode.Left.Size()
+		}
+	}
+	return rank
+}
+
+func (t *OrderedAVLTree[K]) Height() int {
+	return t.Root.Height()
+}
+
+func (t *OrderedAVLTree[K]) Count() int {
+	return t.Root.Size()
+}
+
+func (t *OrderedAVLTree[K]) Print() string {
+	return print(t.Root)
+}
+
+func print[K any](currentNode *OrderedNode[K]) string {
+	if currentNode == nil {
+		return ""
+	}
+	currentPrint := fmt.Sprintf("%v(", currentNode.ID)
+	currentPrint += print(currentNode.Left)
+	currentPrint += print(currentNode.Right)
+	currentPrint += ")"
+	return currentPrint
+}
@@ -0,0 +1,252 @@
+package avltree
+
+import (
+	"github.com/drprado2/go-backend-framework/pkg/entities"
+	"github.com/drprado2/go-backend-framework/pkg/treestructure"
+	"github.com/google/uuid"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// checkInvariants verifies the order, the stored heights and the balance of every node
+func checkInvariants(t *testing.T, tree *AVLTree) {
+	t.Helper()
+	var check func(node *Node, min *int, max *int) int
+	check = func(node *Node, min *int, max *int) int {
+		if node == nil {
+			return 0
+		}
+		if (min != nil && node.ID <= *min) || (max != nil && node.ID >= *max) {
+			t.Fatalf("Node %v breaks the search order", node.ID)
+		}
+		left := check(node.Left, min, &node.ID)
+		right := check(node.Right, &node.ID, max)
+		if left-right > 1 || right-left > 1 {
+			t.Fatalf("Node %v is unbalanced left height %v right height %v", node.ID, left, right)
+		}
+		height := 1 + left
+		if right > left {
+			height = 1 + right
+		}
+		if node.Height() != height {
+			t.Fatalf("Node %v height must be %v got %v", node.ID, height, node.Height())
+		}
+		return height
+	}
+	check(tree.Root, nil, nil)
+}
+
+func inOrderIds(tree *AVLTree) []int {
+	ids := make([]int, 0, tree.Count())
+	tree.InOrder(func(node *Node) bool {
+		ids = append(ids, node.ID)
+		return true
+	})
+	return ids
+}
+
+func buildTestTree() *AVLTree {
+	tree := NewAVLTree()
+	for id := 1; id <= 7; id++ {
+		tree.Add(&Node{ID: id * 10, Data: id})
+	}
+	return tree
+}
+
+func TestAVLTree_AddRotations(t *testing.T) {
+	tree := buildTestTree()
+	expectedPrint := "40(20(10()30())60(50()70()))"
+	if print := tree.Print(); print != expectedPrint {
+		t.Errorf("Invalid print expected\n%v\ngot\n%v", expectedPrint, print)
+	}
+	if tree.Height() != 3 || tree.Count() != 7 {
+		t.Errorf("Height must be 3 and count 7 got %v and %v", tree.Height(), tree.Count())
+	}
+
+	leftRight := NewAVLTree()
+	leftRight.Add(&Node{ID: 30})
+	leftRight.Add(&Node{ID: 10})
+	leftRight.Add(&Node{ID: 20})
+	if print := leftRight.Print(); print != "20(10()30())" {
+		t.Errorf("Left right rotation must make 20 the root got %v", print)
+	}
+}
+
+func TestAVLTree_AddDuplicated(t *testing.T) {
+	tree := buildTestTree()
+	if err := tree.Add(&Node{ID: 30}); err == nil {
+		t.Errorf("Add a duplicated ID must fail")
+	}
+	if tree.Count() != 7 {
+		t.Errorf("Count must be 7 got %v", tree.Count())
+	}
+}
+
+func TestAVLTree_Find(t *testing.T) {
+	tree := buildTestTree()
+	if node := tree.Find(50); node == nil || node.Data != 5 {
+		t.Errorf("Node 50 must have data 5 got %v", node)
+	}
+	if node := tree.Find(55); node != nil {
+		t.Errorf("Node 55 must not exist got %v", node)
+	}
+}
+
+func TestAVLTree_MinMaxFloorCeiling(t *testing.T) {
+	tree := buildTestTree()
+	if tree.Min().ID != 10 || tree.Max().ID != 70 {
+		t.Errorf("Min must be 10 and max 70 got %v and %v", tree.Min().ID, tree.Max().ID)
+	}
+	if node := tree.Floor(45); node == nil || node.ID != 40 {
+		t.Errorf("Floor of 45 must be 40 got %v", node)
+	}
+	if node := tree.Floor(40); node == nil || node.ID != 40 {
+		t.Errorf("Floor of 40 must be 40 got %v", node)
+	}
+	if node := tree.Floor(5); node != nil {
+		t.Errorf("Floor of 5 must be null got %v", node)
+	}
+	if node := tree.Ceiling(45); node == nil || node.ID != 50 {
+		t.Errorf("Ceiling of 45 must be 50 got %v", node)
+	}
+	if node := tree.Ceiling(71); node != nil {
+		t.Errorf("Ceiling of 71 must be null got %v", node)
+	}
+
+	empty := NewAVLTree()
+	if empty.Min() != nil || empty.Max() != nil || empty.Floor(1) != nil || empty.Height() != 0 {
+		t.Errorf("Empty tree must not have nodes")
+	}
+}
+
+func TestAVLTree_InOrderStop(t *testing.T) {
+	tree := buildTestTree()
+	ids := make([]int, 0)
+	tree.InOrder(func(node *Node) bool {
+		ids = append(ids, node.ID)
+		return node.ID < 30
+	})
+	if len(ids) != 3 || ids[2] != 30 {
+		t.Errorf("InOrder must stop at 30 got %v", ids)
+	}
+}
+
+func TestAVLTree_Delete(t *testing.T) {
+	tree := buildTestTree()
+
+	if node, deleted := tree.Delete(40); !deleted || node.ID != 40 || node.Left != nil || node.Right != nil {
+		t.Errorf("Delete of the root 40 must return the unlinked node got %v", node)
+	}
+	if print := tree.Print(); print != "50(20(10()30())60(70()))" {
+		t.Errorf("Successor must replace the root got %v", print)
+	}
+	if _, deleted := tree.Delete(45); deleted {
+		t.Errorf("Delete of a missing node must return false")
+	}
+
+	tree.Delete(10)
+	tree.Delete(30)
+	checkInvariants(t, tree)
+	if tree.Count() != 4 || tree.Find(30) != nil {
+		t.Errorf("Count must be 4 without node 30 got %v", tree.Count())
+	}
+}
+
+func TestAVLTree_RandomOperationsKeepBalance(t *testing.T) {
+	random := rand.New(rand.NewSource(42))
+	tree := NewAVLTree()
+	expected := make(map[int]bool)
+
+	for i := 0; i < 5000; i++ {
+		id := random.Intn(1000)
+		if random.Intn(3) == 0 {
+			_, deleted := tree.Delete(id)
+			if deleted != expected[id] {
+				t.Fatalf("Delete of %v must return %v", id, expected[id])
+			}
+			delete(expected, id)
+		} else {
+			err := tree.Add(&Node{ID: id})
+			if (err == nil) == expected[id] {
+				t.Fatalf("Add of %v must fail only for duplicated IDs got %v", id, err)
+			}
+			expected[id] = true
+		}
+		if i%100 == 0 {
+			checkInvariants(t, tree)
+		}
+	}
+	checkInvariants(t, tree)
+
+	ids := make([]int, 0, len(expected))
+	for id := range expected {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	result := inOrderIds(tree)
+	if len(result) != len(ids) || tree.Count() != len(ids) {
+		t.Fatalf("Tree must have %v nodes got %v", len(ids), len(result))
+	}
+	for i := range ids {
+		if result[i] != ids[i] {
+			t.Fatalf("In order IDs must be sorted got %v at %v expected %v", result[i], i, ids[i])
+		}
+		if node := tree.Kth(i); node == nil || node.ID != ids[i] || tree.Rank(ids[i]) != i {
+			t.Fatalf("Kth %v must be %v got %v", i, ids[i], node)
+		}
+	}
+}
+
+func TestAVLTree_RangeQuery(t *testing.T) {
+	tree := buildTestTree()
+	nodes := tree.RangeQuery(25, 60)
+	if len(nodes) != 4 || nodes[0].ID != 30 || nodes[3].ID != 60 {
+		t.Errorf("Range 25 to 60 must be 30 to 60 got %v", nodes)
+	}
+	if rank := tree.Rank(45); rank != 4 {
+		t.Errorf("Rank of 45 must be 4 got %v", rank)
+	}
+	if node := tree.Kth(6); node == nil || node.ID != 70 {
+		t.Errorf("Kth 6 must be 70 got %v", node)
+	}
+}
+
+func TestAVLTree_SequentialHeight(t *testing.T) {
+	tree := NewAVLTree()
+	for id := 0; id < 1023; id++ {
+		tree.Add(&Node{ID: id})
+	}
+	checkInvariants(t, tree)
+	if tree.Height() != 10 {
+		t.Errorf("Height of 1023 sequential nodes must be 10 got %v", tree.Height())
+	}
+}
+
+func TestOrderedAVLTree_EntityIDKeys(t *testing.T) {
+	tree := NewOrderedAVLTree(treestructure.CompareBytes[entities.ID])
+	ids := make([]entities.ID, 100)
+	for i := range ids {
+		ids[i] = entities.ID(uuid.New())
+		if err := tree.Add(&OrderedNode[entities.ID]{ID: ids[i], Data: i}); err != nil {
+			t.Fatalf("Error adding entity %v\nError: %s", i, err)
+		}
+	}
+
+	for i, id := range ids {
+		if node := tree.Find(id); node == nil || node.Data != i {
+			t.Errorf("Entity %v must be found got %v", i, node)
+		}
+	}
+	previous := tree.Min()
+	tree.InOrder(func(node *OrderedNode[entities.ID]) bool {
+		if treestructure.CompareBytes(previous.ID, node.ID) > 0 {
+			t.Fatalf("In order IDs must be ascending")
+		}
+		previous = node
+		return true
+	})
+	if tree.Height() > 9 {
+		t.Errorf("Height of 100 nodes must be at most 9 got %v", tree.Height())
+	}
+}
@@ -0,0 +1,113 @@
+package treestructure_test
+
+import (
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/treestructure"
+	"github.com/drprado2/go-backend-framework/pkg/treestructure/avltree"
+	"github.com/drprado2/go-backend-framework/pkg/treestructure/binarytree"
+	"github.com/drprado2/go-backend-framework/pkg/treestructure/btree"
+	"github.com/drprado2/go-backend-framework/pkg/treestructure/redblack"
+	"math/rand"
+	"testing"
+)
+
+const benchmarkSize = 10000
+
+func benchmarkKeys(sequential bool) []int {
+	keys := make([]int, benchmarkSize)
+	for i := range keys {
+		keys[i] = i
+	}
+	if !sequential {
+		rand.New(rand.NewSource(1)).Shuffle(len(keys), func(i, j int) {
+			keys[i], keys[j] = keys[j], keys[i]
+		})
+	}
+	return keys
+}
+
+type benchmarkTree struct {
+	name  string
+	build func(keys []int) func(key int) bool
+}
+
+// benchmarkTrees builds each tree with the keys and returns its lookup, the binary search tree uses the
+// first key as root
+func benchmarkTrees() []benchmarkTree {
+	return []benchmarkTree{
+		{"BinarySearchTree", func(keys []int) func(key int) bool {
+			tree, _ := binarytree.NewBinarySearchTree(&binarytree.Node{ID: keys[0]})
+			for _, key := range keys[1:] {
+				tree.Add(&binarytree.Node{ID: key})
+			}
+			return func(key int) bool {
+				node, _ := tree.FindNodeAndFather(key)
+				return node != nil
+			}
+		}},
+		{"AVLTree", func(keys []int) func(key int) bool {
+			tree := avltree.NewAVLTree()
+			for _, key := range keys {
+				tree.Add(&avltree.Node{ID: key})
+			}
+			return func(key int) bool {
+				return tree.Find(key) != nil
+			}
+		}},
+		{"RedBlackTree", func(keys []int) func(key int) bool {
+			return orderedMapLookup(redblack.NewRedBlackTree[int, int](treestructure.CompareOrdered[int]), keys)
+		}},
+		{"BTree32", func(keys []int) func(key int) bool {
+			tree, _ := btree.NewBTree[int, int](32, treestructure.CompareOrdered[int])
+			return orderedMapLookup(tree, keys)
+		}},
+	}
+}
+
+func orderedMapLookup(tree treestructure.OrderedMap[int, int], keys []int) func(key int) bool {
+	for _, key := range keys {
+		tree.Put(key, key)
+	}
+	return func(key int) bool {
+		_, ok := tree.Get(key)
+		return ok
+	}
+}
+
+func BenchmarkTrees_Insert(b *testing.B) {
+	for _, sequential := range []bool{false, true} {
+		keys := benchmarkKeys(sequential)
+		for _, tree := range benchmarkTrees() {
+			build := tree.build
+			b.Run(fmt.Sprintf("%s/sequential=%v", tree.name, sequential), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					build(keys)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkTrees_Get(b *testing.B) {
+	keys := benchmarkKeys(false)
+	for _, tree := range benchmarkTrees() {
+		lookup := tree.build(keys)
+		b.Run(tree.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if !lookup(keys[i%len(keys)]) {
+					b.Fatalf("Key %v must be found", keys[i%len(keys)])
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkLoadBTree(b *testing.B) {
+	entries := make([]treestructure.Entry[int, int], benchmarkSize)
+	for i := range entries {
+		entries[i] = treestructure.Entry[int, int]{Key: i, Value: i}
+	}
+	for i := 0; i < b.N; i++ {
+		btree.LoadBTree(32, treestructure.CompareOrdered[int], entries)
+	}
+}
@@ -3,39 +3,82 @@ package binarytree
 import (
 	"errors"
 	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/treestructure"
 )
 
-type BinarySearchTree struct {
-	Root *Node
+// OrderedBinarySearchTree orders the nodes by ID using the comparator
+type OrderedBinarySearchTree[K any] struct {
+	Root    *OrderedNode[K]
+	compare treestructure.Comparator[K]
 }
 
-type Node struct {
-	Left  *Node
-	Right *Node
-	ID    int
+type OrderedNode[K any] struct {
+	Left  *OrderedNode[K]
+	Right *OrderedNode[K]
+	ID    K
 	Data  interface{}
+	size  int
+}
+
+// Size is the number of nodes of the subtree rooted by the node
+func (n *OrderedNode[K]) Size() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// resize sets the size of every node of the subtree
+func resize[K any](node *OrderedNode[K]) int {
+	if node == nil {
+		return 0
+	}
+	node.size = 1 + resize(node.Left) + resize(node.Right)
+	return node.size
 }
 
+// BinarySearchTree is the int keyed tree
+type BinarySearchTree struct {
+	*OrderedBinarySearchTree[int]
+}
+
+type Node = OrderedNode[int]
+
 func NewBinarySearchTree(root *Node) (*BinarySearchTree, error) {
+	tree, err := NewOrderedBinarySearchTree(root, treestructure.CompareOrdered[int])
+	if err != nil {
+		return nil, err
+	}
+	return &BinarySearchTree{tree}, nil
+}
+
+func NewOrderedBinarySearchTree[K any](root *OrderedNode[K], compare treestructure.Comparator[K]) (*OrderedBinarySearchTree[K], error) {
 	if root == nil {
 		return nil, errors.New("the root node must not be null")
 	}
-	return &BinarySearchTree{
-		Root: root,
+	if compare == nil {
+		return nil, errors.New("the comparator must not be null")
+	}
+	resize(root)
+	return &OrderedBinarySearchTree[K]{
+		Root:    root,
+		compare: compare,
 	}, nil
 }
 
-func (t *BinarySearchTree) Add(node *Node) error {
+func (t *OrderedBinarySearchTree[K]) Add(node *OrderedNode[K]) error {
+	resize(node)
 	currentNode := t.Root
 	for currentNode != nil {
-		if currentNode.ID < node.ID {
+		comparison := t.compare(currentNode.ID, node.ID)
+		if comparison < 0 {
 			if currentNode.Right == nil {
 				currentNode.Right = node
 				break
 			} else {
 				currentNode = currentNode.Right
 			}
-		} else if currentNode.ID > node.ID {
+		} else if comparison > 0 {
 			if currentNode.Left == nil {
 				currentNode.Left = node
 				break
@@ -46,19 +89,33 @@ func (t *BinarySearchTree) Add(node *Node) error {
 			return fmt.Errorf("The element %v already exists in the tree", node.ID)
 		}
 	}
+	t.resizePath(t.Root, node, node.size)
 	return nil
 }
 
-func (t *BinarySearchTree) FindNodeAndFather(nodeId int) (*Node, *Node) {
-	var father *Node
+// resizePath adds the delta to the size of the nodes from the currentNode until the father of the node
+func (t *OrderedBinarySearchTree[K]) resizePath(currentNode *OrderedNode[K], node *OrderedNode[K], delta int) {
+	for currentNode != nil && currentNode != node {
+		currentNode.size += delta
+		if t.compare(currentNode.ID, node.ID) < 0 {
+			currentNode = currentNode.Right
+		} else {
+			currentNode = currentNode.Left
+		}
+	}
+}
+
+func (t *OrderedBinarySearchTree[K]) FindNodeAndFather(nodeId K) (*OrderedNode[K], *OrderedNode[K]) {
+	var father *OrderedNode[K]
 	currentNode := t.Root
 	for currentNode != nil {
-		if currentNode.ID < nodeId {
+		comparison := t.compare(currentNode.ID, nodeId)
+		if comparison < 0 {
 			father = currentNode
 			currentNode = currentNode.Right
 			continue
 		}
-		if currentNode.ID > nodeId {
+		if comparison > 0 {
 			father = currentNode
 			currentNode = currentNode.Left
 			continue
@@ -68,13 +125,13 @@ func (t *BinarySearchTree) FindNodeAndFather(nodeId int) (*Node, *Node) {
 	return nil, nil
 }
 
-func (t *BinarySearchTree) Print() string {
+func (t *OrderedBinarySearchTree[K]) Print() string {
 	result := print(t.Root)
 	fmt.Println(result)
 	return result
 }
 
-func print(currentNode *Node) string {
+func print[K any](currentNode *OrderedNode[K]) string {
 	if currentNode == nil {
 		return ""
 	}
@@ -85,8 +142,8 @@ func print(currentNode *Node) string {
 	return currentPrint
 }
 
-func (t *BinarySearchTree) Delete(nodeId int) (bool, error) {
-	if t.Root.ID == nodeId {
+func (t *OrderedBinarySearchTree[K]) Delete(nodeId K) (bool, error) {
+	if t.compare(t.Root.ID, nodeId) == 0 {
 		return false, errors.New("you can`t delete the root node")
 	}
 	node, father := t.FindNodeAndFather(nodeId)
@@ -94,11 +151,14 @@ func (t *BinarySearchTree) Delete(nodeId int) (bool, error) {
 		return false, nil
 	}
 
-	var newNode *Node = nil
+	var newNode *OrderedNode[K] = nil
+	t.resizePath(t.Root, node, -1)
 
+	// a node with two children is replaced by its predecessor, which also takes the right subtree
 	if node.Left != nil && node.Right != nil {
 		if node.Left.Right == nil {
 			newNode = node.Left
+			newNode.Right = node.Right
 		} else {
 			fatherNode := node.Left
 			currentNode := node.Left.Right
@@ -106,18 +166,20 @@ func (t *BinarySearchTree) Delete(nodeId int) (bool, error) {
 				fatherNode = currentNode
 				currentNode = currentNode.Right
 			}
+			t.resizePath(node.Left, currentNode, -1)
 			fatherNode.Right = currentNode.Left
 			newNode = currentNode
 			newNode.Left = node.Left
 			newNode.Right = node.Right
 		}
+		newNode.size = node.size - 1
 	} else if node.Right != nil {
 		newNode = node.Right
 	} else if node.Left != nil {
 		newNode = node.Left
 	}
 
-	if father.ID > node.ID {
+	if t.compare(father.ID, node.ID) > 0 {
 		father.Left = newNode
 	} else {
 		father.Right = newNode
@@ -125,16 +187,103 @@ func (t *BinarySearchTree) Delete(nodeId int) (bool, error) {
 	return true, nil
 }
 
-func (t *BinarySearchTree) Count() int {
-	return count(t.Root)
+func (t *OrderedBinarySearchTree[K]) Count() int {
+	return t.Root.Size()
 }
 
-func count(currentNode *Node) int {
-	if currentNode == nil {
-		return 0
+// Floor returns the node with the greatest ID less than or equal to the nodeId
+func (t *OrderedBinarySearchTree[K]) Floor(nodeId K) *OrderedNode[K] {
+	var floor *OrderedNode[K]
+	currentNode := t.Root
+	for currentNode != nil {
+		comparison := t.compare(currentNode.ID, nodeId)
+		if comparison == 0 {
+			return currentNode
+		}
+		if comparison < 0 {
+			floor = currentNode
+			currentNode = currentNode.Right
+		} else {
+			currentNode = currentNode.Left
+		}
 	}
-	result := 1
-	result += count(currentNode.Left)
-	result += count(currentNode.Right)
+	return floor
+}
+
+// Ceiling returns the node with the smallest ID greater than or equal to the nodeId
+func (t *OrderedBinarySearchTree[K]) Ceiling(nodeId K) *OrderedNode[K] {
+	var ceiling *OrderedNode[K]
+	currentNode := t.Root
+	for currentNode != nil {
+		comparison := t.compare(currentNode.ID, nodeId)
+		if comparison == 0 {
+			return currentNode
+		}
+		if comparison > 0 {
+			ceiling = currentNode
+			currentNode = currentNode.Left
+		} else {
+			currentNode = currentNode.Right
+		}
+	}
+	return ceiling
+}
+
+// RangeQuery returns the nodes with ID between lo and hi inclusive in ascending order
+func (t *OrderedBinarySearchTree[K]) RangeQuery(lo K, hi K) []*OrderedNode[K] {
+	result := make([]*OrderedNode[K], 0)
+	var query func(currentNode *OrderedNode[K])
+	query = func(currentNode *OrderedNode[K]) {
+		if currentNode == nil {
+			return
+		}
+		aboveLo := t.compare(currentNode.ID, lo) >= 0
+		belowHi := t.compare(currentNode.ID, hi) <= 0
+		if aboveLo {
+			query(currentNode.Left)
+		}
+		if aboveLo && belowHi {
+			result = append(result, currentNode)
+		}
+		if belowHi {
+			query(currentNode.Right)
+		}
+	}
+	query(t.Root)
 	return result
 }
+
+// Kth returns the node at the zero based position k of the ascending order, null when k is out of range
+func (t *OrderedBinarySearchTree[K]) Kth(k int) *OrderedNode[K] {
+	currentNode := t.Root
+	for currentNode != nil {
+		leftSize := currentNode.Left.Size()
+		if k < leftSize {
+			currentNode = currentNode.Left
+		} else if k > leftSize {
+			k -= leftSize + 1
+			currentNode = currentNode.Right
+		} else {
+			return currentNode
+		}
+	}
+	return nil
+}
+
+// Rank returns how many nodes have ID less than the nodeId, so Kth(Rank(id)) is the node of the id
+func (t *OrderedBinarySearchTree[K]) Rank(nodeId K) int {
+	rank := 0
+	currentNode := t.Root
+	for currentNode != nil {
+		comparison := t.compare(currentNode.ID, nodeId)
+		if comparison < 0 {
+			rank += currentNode.Left.Size() + 1
+			currentNode = currentNode.Right
+		} else if comparison > 0 {
+			currentNode = currentNode.Left
+		} else {
+			return rank + currentNode.Left.Size()
+		}
+	}
+	return rank
+}
@@ -1,7 +1,12 @@
 package binarytree
 
 import (
+	"github.com/drprado2/go-backend-framework/pkg/treestructure"
+	"math/rand"
+	"reflect"
+	"sort"
 	"testing"
+	"time"
 )
 
 func buildTestTree() *BinarySearchTree {
@@ -130,3 +135,179 @@ func TestBinarySearchTree_Delete(t *testing.T) {
 		t.Errorf("Invalid print expetected\n%v\ngot\n%v", expectedPrint, print)
 	}
 }
+
+func TestBinarySearchTree_DeleteWithLeftChildWithoutRight(t *testing.T) {
+	tree, _ := NewBinarySearchTree(&Node{ID: 10})
+	tree.Add(&Node{ID: 5})
+	tree.Add(&Node{ID: 20})
+	tree.Add(&Node{ID: 15})
+	tree.Add(&Node{ID: 25})
+
+	tree.Delete(20)
+	expectedPrint := "10(5()15(25()))"
+	if print := tree.Print(); print != expectedPrint {
+		t.Errorf("Invalid print expetected\n%v\ngot\n%v", expectedPrint, print)
+	}
+}
+
+func TestBinarySearchTree_DeleteWithTwoChildrenKeepsRightSubtree(t *testing.T) {
+	// 20 is replaced by its left child 15 and then by the predecessor 17 deeper in the left subtree
+	cases := [][]int{
+		{5, 20, 15, 25, 30},
+		{5, 20, 15, 25, 30, 17},
+	}
+	for _, added := range cases {
+		tree, _ := NewBinarySearchTree(&Node{ID: 10})
+		for _, id := range added {
+			tree.Add(&Node{ID: id})
+		}
+		expected := []int{10}
+		for _, id := range added {
+			if id != 20 {
+				expected = append(expected, id)
+			}
+		}
+		sort.Ints(expected)
+
+		tree.Delete(20)
+		if count := tree.Count(); count != len(expected) {
+			t.Errorf("Count must be %v got %v", len(expected), count)
+		}
+		ids := make([]int, 0, len(expected))
+		for iterator := tree.InOrderIterator(); iterator.Next(); {
+			ids = append(ids, iterator.Node().ID)
+		}
+		if !reflect.DeepEqual(ids, expected) {
+			t.Errorf("In order IDs must be %v got %v", expected, ids)
+		}
+	}
+}
+
+func TestOrderedBinarySearchTree_StringKeys(t *testing.T) {
+	tree, _ := NewOrderedBinarySearchTree(&OrderedNode[string]{ID: "m"}, treestructure.CompareOrdered[string])
+	for _, id := range []string{"c", "x", "a", "p"} {
+		tree.Add(&OrderedNode[string]{ID: id, Data: id + id})
+	}
+	if err := tree.Add(&OrderedNode[string]{ID: "p"}); err == nil {
+		t.Errorf("Add a duplicated key must fail")
+	}
+	expectedPrint := "m(c(a())x(p()))"
+	if print := tree.Print(); print != expectedPrint {
+		t.Errorf("Invalid print expetected\n%v\ngot\n%v", expectedPrint, print)
+	}
+	if node, father := tree.FindNodeAndFather("p"); node == nil || node.Data != "pp" || father.ID != "x" {
+		t.Errorf("Search result of node p is wrong\nNode: %v\nFather: %v", node, father)
+	}
+}
+
+func TestOrderedBinarySearchTree_TimeKeys(t *testing.T) {
+	now := time.Now()
+	tree, _ := NewOrderedBinarySearchTree(&OrderedNode[time.Time]{ID: now}, treestructure.CompareTime)
+	tree.Add(&OrderedNode[time.Time]{ID: now.Add(-time.Hour)})
+	tree.Add(&OrderedNode[time.Time]{ID: now.Add(time.Hour)})
+
+	if tree.Root.Left == nil || !tree.Root.Left.ID.Before(now) || tree.Root.Right == nil || !tree.Root.Right.ID.After(now) {
+		t.Errorf("Earlier times must be on the left and later on the right")
+	}
+	if deleted, _ := tree.Delete(now.Add(time.Hour)); !deleted || tree.Count() != 2 {
+		t.Errorf("Delete must remove the later time got count %v", tree.Count())
+	}
+}
+
+func TestOrderedBinarySearchTree_NullComparator(t *testing.T) {
+	if tree, err := NewOrderedBinarySearchTree[string](&OrderedNode[string]{ID: "a"}, nil); tree != nil || err == nil {
+		t.Errorf("Tree without comparator must fail got %v", tree)
+	}
+}
+
+func TestBinarySearchTree_FloorCeiling(t *testing.T) {
+	tree, _ := NewBinarySearchTree(&Node{ID: 20})
+	for _, id := range []int{10, 30, 25, 40} {
+		tree.Add(&Node{ID: id})
+	}
+	if node := tree.Floor(27); node == nil || node.ID != 25 {
+		t.Errorf("Floor of 27 must be 25 got %v", node)
+	}
+	if node := tree.Floor(9); node != nil {
+		t.Errorf("Floor of 9 must be null got %v", node)
+	}
+	if node := tree.Ceiling(27); node == nil || node.ID != 30 {
+		t.Errorf("Ceiling of 27 must be 30 got %v", node)
+	}
+	if node := tree.Ceiling(40); node == nil || node.ID != 40 {
+		t.Errorf("Ceiling of 40 must be 40 got %v", node)
+	}
+	if node := tree.Ceiling(41); node != nil {
+		t.Errorf("Ceiling of 41 must be null got %v", node)
+	}
+}
+
+func TestBinarySearchTree_RangeQuery(t *testing.T) {
+	tree := buildTestTree()
+	nodes := tree.RangeQuery(4, 9)
+	if len(nodes) != 6 {
+		t.Fatalf("Range 4 to 9 must have 6 nodes got %v", len(nodes))
+	}
+	for i, node := range nodes {
+		if node.ID != i+4 {
+			t.Errorf("Range nodes must be ascending got %v at %v", node.ID, i)
+		}
+	}
+	if nodes := tree.RangeQuery(16, 20); len(nodes) != 0 {
+		t.Errorf("Range 16 to 20 must be empty got %v", len(nodes))
+	}
+}
+
+func TestBinarySearchTree_KthAndRank(t *testing.T) {
+	tree := buildTestTree()
+	tree.Delete(12)
+	tree.Delete(3)
+	expected := []int{0, 1, 2, 4, 5, 6, 7, 8, 9, 10, 11, 13, 14, 15}
+	for i, id := range expected {
+		if node := tree.Kth(i); node == nil || node.ID != id {
+			t.Errorf("Kth %v must be %v got %v", i, id, node)
+		}
+		if rank := tree.Rank(id); rank != i {
+			t.Errorf("Rank of %v must be %v got %v", id, i, rank)
+		}
+	}
+	if rank := tree.Rank(12); rank != 11 {
+		t.Errorf("Rank of the missing 12 must be 11 got %v", rank)
+	}
+	if tree.Kth(-1) != nil || tree.Kth(len(expected)) != nil {
+		t.Errorf("Kth out of range must be null")
+	}
+}
+
+func TestBinarySearchTree_RandomOperationsKeepSizes(t *testing.T) {
+	random := rand.New(rand.NewSource(7))
+	tree, _ := NewBinarySearchTree(&Node{ID: 500})
+	expected := map[int]bool{500: true}
+
+	for i := 0; i < 3000; i++ {
+		id := random.Intn(1000)
+		if id == 500 {
+			continue
+		}
+		if random.Intn(3) == 0 {
+			tree.Delete(id)
+			delete(expected, id)
+		} else if tree.Add(&Node{ID: id}) == nil {
+			expected[id] = true
+		}
+	}
+
+	ids := make([]int, 0, len(expected))
+	for id := range expected {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	if tree.Count() != len(ids) {
+		t.Fatalf("Count must be %v got %v", len(ids), tree.Count())
+	}
+	for i, id := range ids {
+		if node := tree.Kth(i); node == nil || node.ID != id || tree.Rank(id) != i {
+			t.Fatalf("Kth %v must be %v got %v", i, id, node)
+		}
+	}
+}
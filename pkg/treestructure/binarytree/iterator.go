@@ -0,0 +1,116 @@
+package binarytree
+
+// Iterator walks the tree lazily, the tree must not change while iterating
+//
+//	iterator := tree.InOrderIterator()
+//	for iterator.Next() {
+//		node := iterator.Node()
+//	}
+type Iterator[K any] struct {
+	next    func() *OrderedNode[K]
+	current *OrderedNode[K]
+}
+
+func (it *Iterator[K]) Next() bool {
+	it.current = it.next()
+	return it.current != nil
+}
+
+func (it *Iterator[K]) Node() *OrderedNode[K] {
+	return it.current
+}
+
+// InOrderIterator visits the nodes by ascending ID
+func (t *OrderedBinarySearchTree[K]) InOrderIterator() *Iterator[K] {
+	return newSortedIterator(t.Root, false)
+}
+
+// ReverseIterator visits the nodes by descending ID
+func (t *OrderedBinarySearchTree[K]) ReverseIterator() *Iterator[K] {
+	return newSortedIterator(t.Root, true)
+}
+
+func newSortedIterator[K any](root *OrderedNode[K], reverse bool) *Iterator[K] {
+	stack := make([]*OrderedNode[K], 0)
+	first := func(node *OrderedNode[K]) *OrderedNode[K] {
+		if reverse {
+			return node.Right
+		}
+		return node.Left
+	}
+	second := func(node *OrderedNode[K]) *OrderedNode[K] {
+		if reverse {
+			return node.Left
+		}
+		return node.Right
+	}
+	pushAll := func(node *OrderedNode[K]) {
+		for ; node != nil; node = first(node) {
+			stack = append(stack, node)
+		}
+	}
+	pushAll(root)
+
+	return &Iterator[K]{
+		next: func() *OrderedNode[K] {
+			if len(stack) == 0 {
+				return nil
+			}
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			pushAll(second(node))
+			return node
+		},
+	}
+}
+
+// PreOrderIterator visits each node before its left and right subtrees
+func (t *OrderedBinarySearchTree[K]) PreOrderIterator() *Iterator[K] {
+	stack := make([]*OrderedNode[K], 0)
+	if t.Root != nil {
+		stack = append(stack, t.Root)
+	}
+	return &Iterator[K]{
+		next: func() *OrderedNode[K] {
+			if len(stack) == 0 {
+				return nil
+			}
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if node.Right != nil {
+				stack = append(stack, node.Right)
+			}
+			if node.Left != nil {
+				stack = append(stack, node.Left)
+			}
+			return node
+		},
+	}
+}
+
+// PostOrderIterator visits each node after its left and right subtrees
+func (t *OrderedBinarySearchTree[K]) PostOrderIterator() *Iterator[K] {
+	stack := make([]*OrderedNode[K], 0)
+	currentNode := t.Root
+	var lastVisited *OrderedNode[K]
+	return &Iterator[K]{
+		next: func() *OrderedNode[K] {
+			for currentNode != nil || len(stack) > 0 {
+				if currentNode != nil {
+					stack = append(stack, currentNode)
+					currentNode = currentNode.Left
+					continue
+				}
+				top := stack[len(stack)-1]
+				if top.Right != nil && top.Right != lastVisited {
+					currentNode = top.Right
+					continue
+				}
+				stack = stack[:len(stack)-1]
+				lastVisited = top
+				return top
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package binarytree
+
+import (
+	"fmt"
+	"testing"
+)
+
+func iteratorIds(iterator *Iterator[int]) string {
+	ids := make([]int, 0)
+	for iterator.Next() {
+		ids = append(ids, iterator.Node().ID)
+	}
+	return fmt.Sprint(ids)
+}
+
+func TestBinarySearchTree_Iterators(t *testing.T) {
+	tree := buildTestTree()
+	expected := map[string]struct {
+		iterator *Iterator[int]
+		ids      string
+	}{
+		"in order":   {tree.InOrderIterator(), "[0 1 2 3 4 5 6 7 8 9 10 11 12 13 14 15]"},
+		"reverse":    {tree.ReverseIterator(), "[15 14 13 12 11 10 9 8 7 6 5 4 3 2 1 0]"},
+		"pre order":  {tree.PreOrderIterator(), "[7 3 1 0 2 6 4 5 12 9 8 11 10 13 15 14]"},
+		"post order": {tree.PostOrderIterator(), "[0 2 1 5 4 6 3 8 10 11 9 14 15 13 12 7]"},
+	}
+	for name, test := range expected {
+		if ids := iteratorIds(test.iterator); ids != test.ids {
+			t.Errorf("%s iterator must be %s got %s", name, test.ids, ids)
+		}
+	}
+}
+
+func TestBinarySearchTree_IteratorsSingleNode(t *testing.T) {
+	tree, _ := NewBinarySearchTree(&Node{ID: 1})
+	for _, iterator := range []*Iterator[int]{tree.InOrderIterator(), tree.ReverseIterator(), tree.PreOrderIterator(), tree.PostOrderIterator()} {
+		if ids := iteratorIds(iterator); ids != "[1]" {
+			t.Errorf("Iterator must visit only the root got %s", ids)
+		}
+		if iterator.Next() {
+			t.Errorf("Finished iterator must not have next")
+		}
+	}
+}
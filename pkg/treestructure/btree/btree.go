@@ -0,0 +1,370 @@
+package btree
+
+import (
+	"errors"
+	"fmt"
+	"github.com/drprado2/go-backend-framework/pkg/treestructure"
+	"sort"
+)
+
+const MinOrder = 3
+
+type node[K any, V any] struct {
+	keys     []K
+	values   []V
+	children []*node[K, V]
+}
+
+func (n *node[K, V]) leaf() bool {
+	return len(n.children) == 0
+}
+
+// BTree keeps every leaf at the same depth, each node has at most order children and every node but
+// the root has at least half of them, the keys of a node are between the keys of its children
+type BTree[K any, V any] struct {
+	root    *node[K, V]
+	order   int
+	length  int
+	compare treestructure.Comparator[K]
+}
+
+var _ treestructure.OrderedMap[int, int] = (*BTree[int, int])(nil)
+
+func NewBTree[K any, V any](order int, compare treestructure.Comparator[K]) (*BTree[K, V], error) {
+	if order < MinOrder {
+		return nil, fmt.Errorf("The order must be at least %v got %v", MinOrder, order)
+	}
+	if compare == nil {
+		return nil, errors.New("the comparator must not be null")
+	}
+	return &BTree[K, V]{
+		order:   order,
+		compare: compare,
+	}, nil
+}
+
+// LoadBTree builds the tree bottom up from entries sorted by ascending key without duplicates, it is
+// faster than putting the entries one by one and leaves the nodes evenly filled
+func LoadBTree[K any, V any](order int, compare treestructure.Comparator[K], entries []treestructure.Entry[K, V]) (*BTree[K, V], error) {
+	tree, err := NewBTree[K, V](order, compare)
+	if err != nil {
+		return nil, err
+	}
+	for i := 1; i < len(entries); i++ {
+		if compare(entries[i-1].Key, entries[i].Key) >= 0 {
+			return nil, fmt.Errorf("The entries must be sorted by ascending key without duplicates, key %v is out of order", entries[i].Key)
+		}
+	}
+	if len(entries) == 0 {
+		return tree, nil
+	}
+
+	keys := make([]K, len(entries))
+	values := make([]V, len(entries))
+	for i, entry := range entries {
+		keys[i], values[i] = entry.Key, entry.Value
+	}
+	var children []*node[K, V]
+	for {
+		// the fewest nodes that hold the level, each pair of nodes is separated by a key of the level above
+		count := (len(keys) + order) / order
+		remaining := len(keys) - (count - 1)
+		nodes := make([]*node[K, V], count)
+		parentKeys := make([]K, 0, count-1)
+		parentValues := make([]V, 0, count-1)
+		start, childStart := 0, 0
+		for i := range nodes {
+			size := remaining / count
+			if i < remaining%count {
+				size++
+			}
+			nodes[i] = &node[K, V]{
+				keys:   append([]K(nil), keys[start:start+size]...),
+				values: append([]V(nil), values[start:start+size]...),
+			}
+			if children != nil {
+				nodes[i].children = append([]*node[K, V](nil), children[childStart:childStart+size+1]...)
+				childStart += size + 1
+			}
+			start += size
+			if i < count-1 {
+				parentKeys = append(parentKeys, keys[start])
+				parentValues = append(parentValues, values[start])
+				start++
+			}
+		}
+		if count == 1 {
+			tree.root = nodes[0]
+			break
+		}
+		keys, values, children = parentKeys, parentValues, nodes
+	}
+	tree.length = len(entries)
+	return tree, nil
+}
+
+func (t *BTree[K, V]) Order() int {
+	return t.order
+}
+
+func (t *BTree[K, V]) minKeys() int {
+	return (t.order+1)/2 - 1
+}
+
+// search returns the position of the first key greater than or equal to the key
+func (t *BTree[K, V]) search(n *node[K, V], key K) (int, bool) {
+	i := sort.Search(len(n.keys), func(i int) bool {
+		return t.compare(n.keys[i], key) >= 0
+	})
+	return i, i < len(n.keys) && t.compare(n.keys[i], key) == 0
+}
+
+func insertAt[T any](items []T, i int, item T) []T {
+	items = append(items, item)
+	copy(items[i+1:], items[i:])
+	items[i] = item
+	return items
+}
+
+func removeAt[T any](items []T, i int) []T {
+	copy(items[i:], items[i+1:])
+	return items[:len(items)-1]
+}
+
+func (t *BTree[K, V]) Put(key K, value V) bool {
+	if t.root == nil {
+		t.root = &node[K, V]{keys: []K{key}, values: []V{value}}
+		t.length++
+		return true
+	}
+	added, medianKey, medianValue, right := t.put(t.root, key, value)
+	if right != nil {
+		t.root = &node[K, V]{
+			keys:     []K{medianKey},
+			values:   []V{medianValue},
+			children: []*node[K, V]{t.root, right},
+		}
+	}
+	if added {
+		t.length++
+	}
+	return added
+}
+
+// put returns the median and the new right node when the node was split
+func (t *BTree[K, V]) put(n *node[K, V], key K, value V) (bool, K, V, *node[K, V]) {
+	var medianKey K
+	var medianValue V
+	i, found := t.search(n, key)
+	if found {
+		n.values[i] = value
+		return false, medianKey, medianValue, nil
+	}
+
+	added := true
+	if n.leaf() {
+		n.keys = insertAt(n.keys, i, key)
+		n.values = insertAt(n.values, i, value)
+	} else {
+		var childKey K
+		var childValue V
+		var right *node[K, V]
+		added, childKey, childValue, right = t.put(n.children[i], key, value)
+		if right != nil {
+			n.keys = insertAt(n.keys, i, childKey)
+			n.values = insertAt(n.values, i, childValue)
+			n.children = insertAt(n.children, i+1, right)
+		}
+	}
+	if len(n.keys) < t.order {
+		return added, medianKey, medianValue, nil
+	}
+
+	middle := len(n.keys) / 2
+	medianKey, medianValue = n.keys[middle], n.values[middle]
+	right := &node[K, V]{
+		keys:   append([]K(nil), n.keys[middle+1:]...),
+		values: append([]V(nil), n.values[middle+1:]...),
+	}
+	if !n.leaf() {
+		right.children = append([]*node[K, V](nil), n.children[middle+1:]...)
+		n.children = n.children[:middle+1]
+	}
+	n.keys = n.keys[:middle]
+	n.values = n.values[:middle]
+	return added, medianKey, medianValue, right
+}
+
+func (t *BTree[K, V]) Get(key K) (V, bool) {
+	current := t.root
+	for current != nil {
+		i, found := t.search(current, key)
+		if found {
+			return current.values[i], true
+		}
+		if current.leaf() {
+			break
+		}
+		current = current.children[i]
+	}
+	var zero V
+	return zero, false
+}
+
+func (t *BTree[K, V]) Delete(key K) bool {
+	if t.root == nil || !t.delete(t.root, key) {
+		return false
+	}
+	t.length--
+	if len(t.root.keys) == 0 {
+		if t.root.leaf() {
+			t.root = nil
+		} else {
+			t.root = t.root.children[0]
+		}
+	}
+	return true
+}
+
+func (t *BTree[K, V]) delete(n *node[K, V], key K) bool {
+	i, found := t.search(n, key)
+	if n.leaf() {
+		if !found {
+			return false
+		}
+		n.keys = removeAt(n.keys, i)
+		n.values = removeAt(n.values, i)
+		return true
+	}
+
+	if found {
+		// the predecessor takes the place of the key and is deleted from the left child
+		predecessor := n.children[i]
+		for !predecessor.leaf() {
+			predecessor = predecessor.children[len(predecessor.children)-1]
+		}
+		last := len(predecessor.keys) - 1
+		n.keys[i], n.values[i] = predecessor.keys[last], predecessor.values[last]
+		t.delete(n.children[i], n.keys[i])
+	} else if !t.delete(n.children[i], key) {
+		return false
+	}
+	t.fill(n, i)
+	return true
+}
+
+// fill borrows a key from a sibling or merges the child i with one when it has too few keys
+func (t *BTree[K, V]) fill(n *node[K, V], i int) {
+	child := n.children[i]
+	if len(child.keys) >= t.minKeys() {
+		return
+	}
+
+	if i > 0 && len(n.children[i-1].keys) > t.minKeys() {
+		left := n.children[i-1]
+		last := len(left.keys) - 1
+		child.keys = insertAt(child.keys, 0, n.keys[i-1])
+		child.values = insertAt(child.values, 0, n.values[i-1])
+		n.keys[i-1], n.values[i-1] = left.keys[last], left.values[last]
+		left.keys, left.values = left.keys[:last], left.values[:last]
+		if !left.leaf() {
+			child.children = insertAt(child.children, 0, left.children[last+1])
+			left.children = left.children[:last+1]
+		}
+		return
+	}
+	if i < len(n.keys) && len(n.children[i+1].keys) > t.minKeys() {
+		right := n.children[i+1]
+		child.keys = append(child.keys, n.keys[i])
+		child.values = append(child.values, n.values[i])
+		n.keys[i], n.values[i] = right.keys[0], right.values[0]
+		right.keys, right.values = removeAt(right.keys, 0), removeAt(right.values, 0)
+		if !right.leaf() {
+			child.children = append(child.children, right.children[0])
+			right.children = removeAt(right.children, 0)
+		}
+		return
+	}
+
+	if i == len(n.keys) {
+		i--
+	}
+	left, right := n.children[i], n.children[i+1]
+	left.keys = append(append(left.keys, n.keys[i]), right.keys...)
+	left.values = append(append(left.values, n.values[i]), right.values...)
+	left.children = append(left.children, right.children...)
+	n.keys = removeAt(n.keys, i)
+	n.values = removeAt(n.values, i)
+	n.children = removeAt(n.children, i+1)
+}
+
+func (t *BTree[K, V]) Min() (K, V, bool) {
+	if t.root == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	current := t.root
+	for !current.leaf() {
+		current = current.children[0]
+	}
+	return current.keys[0], current.values[0], true
+}
+
+func (t *BTree[K, V]) Max() (K, V, bool) {
+	if t.root == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	current := t.root
+	for !current.leaf() {
+		current = current.children[len(current.children)-1]
+	}
+	last := len(current.keys) - 1
+	return current.keys[last], current.values[last], true
+}
+
+func (t *BTree[K, V]) Range(lo K, hi K, visit func(key K, value V) bool) {
+	var walk func(current *node[K, V]) bool
+	walk = func(current *node[K, V]) bool {
+		i, _ := t.search(current, lo)
+		for ; i <= len(current.keys); i++ {
+			if !current.leaf() && !walk(current.children[i]) {
+				return false
+			}
+			if i == len(current.keys) {
+				break
+			}
+			if t.compare(current.keys[i], hi) > 0 || !visit(current.keys[i], current.values[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if t.root != nil {
+		walk(t.root)
+	}
+}
+
+func (t *BTree[K, V]) Ascend(visit func(key K, value V) bool) {
+	var walk func(current *node[K, V]) bool
+	walk = func(current *node[K, V]) bool {
+		for i := 0; i <= len(current.keys); i++ {
+			if !current.leaf() && !walk(current.children[i]) {
+				return false
+			}
+			if i < len(current.keys) && !visit(current.keys[i], current.values[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if t.root != nil {
+		walk(t.root)
+	}
+}
+
+func (t *BTree[K, V]) Len() int {
+	return t.length
+}
@@ -0,0 +1,196 @@
+package btree
+
+import (
+	"github.com/drprado2/go-backend-framework/pkg/treestructure"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// checkInvariants verifies the order, the number of keys and children of every node and that all the
+// leaves have the same depth
+func checkInvariants(t *testing.T, tree *BTree[int, int]) {
+	t.Helper()
+	if tree.root == nil {
+		return
+	}
+	leafDepth := -1
+	var check func(n *node[int, int], depth int, min *int, max *int)
+	check = func(n *node[int, int], depth int, min *int, max *int) {
+		if len(n.keys) >= tree.order || (n != tree.root && len(n.keys) < tree.minKeys()) {
+			t.Fatalf("Node with %v keys breaks the order %v", len(n.keys), tree.order)
+		}
+		for i, key := range n.keys {
+			if (min != nil && key <= *min) || (max != nil && key >= *max) || (i > 0 && key <= n.keys[i-1]) {
+				t.Fatalf("Key %v breaks the search order", key)
+			}
+		}
+		if n.leaf() {
+			if leafDepth == -1 {
+				leafDepth = depth
+			} else if leafDepth != depth {
+				t.Fatalf("Leaves must have the same depth got %v and %v", leafDepth, depth)
+			}
+			return
+		}
+		if len(n.children) != len(n.keys)+1 {
+			t.Fatalf("Node with %v keys must have %v children got %v", len(n.keys), len(n.keys)+1, len(n.children))
+		}
+		for i, child := range n.children {
+			childMin, childMax := min, max
+			if i > 0 {
+				childMin = &n.keys[i-1]
+			}
+			if i < len(n.keys) {
+				childMax = &n.keys[i]
+			}
+			check(child, depth+1, childMin, childMax)
+		}
+	}
+	check(tree.root, 0, nil, nil)
+}
+
+func ascendKeys(tree *BTree[int, int]) []int {
+	keys := make([]int, 0, tree.Len())
+	tree.Ascend(func(key int, value int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+func TestNewBTree_InvalidOrder(t *testing.T) {
+	if tree, err := NewBTree[int, int](2, treestructure.CompareOrdered[int]); tree != nil || err == nil {
+		t.Errorf("Order 2 must fail got %v", tree)
+	}
+	if tree, err := NewBTree[int, int](3, nil); tree != nil || err == nil {
+		t.Errorf("Null comparator must fail got %v", tree)
+	}
+}
+
+func TestBTree_PutGetRange(t *testing.T) {
+	tree, _ := NewBTree[int, int](3, treestructure.CompareOrdered[int])
+	for key := 1; key <= 20; key++ {
+		tree.Put(key*10, key)
+	}
+	checkInvariants(t, tree)
+	if value, ok := tree.Get(130); !ok || value != 13 {
+		t.Errorf("Key 130 must have value 13 got %v", value)
+	}
+	if _, ok := tree.Get(135); ok {
+		t.Errorf("Key 135 must not exist")
+	}
+	if tree.Put(130, 0) || tree.Len() != 20 {
+		t.Errorf("Put of an existing key must replace the value, len must be 20 got %v", tree.Len())
+	}
+	if key, _, _ := tree.Min(); key != 10 {
+		t.Errorf("Min must be 10 got %v", key)
+	}
+	if key, _, _ := tree.Max(); key != 200 {
+		t.Errorf("Max must be 200 got %v", key)
+	}
+
+	keys := make([]int, 0)
+	tree.Range(45, 200, func(key int, value int) bool {
+		keys = append(keys, key)
+		return key < 90
+	})
+	if len(keys) != 5 || keys[0] != 50 || keys[4] != 90 {
+		t.Errorf("Range must visit 50 to 90 and stop got %v", keys)
+	}
+	keys = keys[:0]
+	tree.Range(201, 300, func(key int, value int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if len(keys) != 0 {
+		t.Errorf("Range after the max must be empty got %v", keys)
+	}
+}
+
+func TestBTree_RandomOperationsKeepInvariants(t *testing.T) {
+	for _, order := range []int{3, 4, 5, 32} {
+		random := rand.New(rand.NewSource(int64(order)))
+		tree, _ := NewBTree[int, int](order, treestructure.CompareOrdered[int])
+		expected := make(map[int]int)
+
+		for i := 0; i < 5000; i++ {
+			key := random.Intn(1000)
+			_, exists := expected[key]
+			if random.Intn(3) == 0 {
+				if tree.Delete(key) != exists {
+					t.Fatalf("Order %v delete of %v must return %v", order, key, exists)
+				}
+				delete(expected, key)
+			} else {
+				if tree.Put(key, i) == exists {
+					t.Fatalf("Order %v put of %v must return %v", order, key, !exists)
+				}
+				expected[key] = i
+			}
+			if i%100 == 0 {
+				checkInvariants(t, tree)
+			}
+		}
+		checkInvariants(t, tree)
+
+		keys := make([]int, 0, len(expected))
+		for key := range expected {
+			keys = append(keys, key)
+			if value, ok := tree.Get(key); !ok || value != expected[key] {
+				t.Fatalf("Order %v key %v must have value %v got %v", order, key, expected[key], value)
+			}
+		}
+		sort.Ints(keys)
+		result := ascendKeys(tree)
+		if len(result) != len(keys) || tree.Len() != len(keys) {
+			t.Fatalf("Order %v tree must have %v keys got %v", order, len(keys), len(result))
+		}
+		for i := range keys {
+			if result[i] != keys[i] {
+				t.Fatalf("Order %v keys must be ascending got %v at %v", order, result[i], i)
+			}
+		}
+
+		for _, key := range keys {
+			tree.Delete(key)
+		}
+		if tree.Len() != 0 || tree.root != nil {
+			t.Errorf("Order %v tree must be empty got %v keys", order, tree.Len())
+		}
+	}
+}
+
+func TestLoadBTree(t *testing.T) {
+	for _, order := range []int{3, 4, 7} {
+		for _, size := range []int{0, 1, 2, 3, 10, 100, 1001} {
+			entries := make([]treestructure.Entry[int, int], size)
+			for i := range entries {
+				entries[i] = treestructure.Entry[int, int]{Key: i * 2, Value: i}
+			}
+			tree, err := LoadBTree(order, treestructure.CompareOrdered[int], entries)
+			if err != nil {
+				t.Fatalf("Error loading %v entries with order %v\nError: %s", size, order, err)
+			}
+			checkInvariants(t, tree)
+			if keys := ascendKeys(tree); tree.Len() != size || len(keys) != size {
+				t.Fatalf("Loaded tree must have %v keys got %v", size, len(keys))
+			}
+			if size > 0 {
+				if value, ok := tree.Get((size - 1) * 2); !ok || value != size-1 {
+					t.Errorf("Last key must have the value %v got %v", size-1, value)
+				}
+				tree.Put(1, -1)
+				tree.Delete(0)
+				checkInvariants(t, tree)
+			}
+		}
+	}
+}
+
+func TestLoadBTree_Unsorted(t *testing.T) {
+	entries := []treestructure.Entry[string, int]{{Key: "a"}, {Key: "c"}, {Key: "c"}}
+	if tree, err := LoadBTree(4, treestructure.CompareOrdered[string], entries); tree != nil || err == nil {
+		t.Errorf("Duplicated keys must fail got %v", tree)
+	}
+}
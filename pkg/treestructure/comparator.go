@@ -0,0 +1,24 @@
+package treestructure
+
+import (
+	"bytes"
+	"cmp"
+	"time"
+)
+
+// Comparator returns a negative number when a is less than b, zero when they are equal and a positive
+// number when a is greater than b
+type Comparator[K any] func(a K, b K) int
+
+func CompareOrdered[K cmp.Ordered](a K, b K) int {
+	return cmp.Compare(a, b)
+}
+
+func CompareTime(a time.Time, b time.Time) int {
+	return a.Compare(b)
+}
+
+// CompareBytes orders fixed size byte arrays like uuid.UUID and entities.ID
+func CompareBytes[K ~[16]byte](a K, b K) int {
+	return bytes.Compare(a[:], b[:])
+}
@@ -0,0 +1,22 @@
+package treestructure
+
+// Entry is a key and its value stored in an OrderedMap
+type Entry[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// OrderedMap keeps the values sorted by key, the visit functions are called by ascending key until they
+// return false
+type OrderedMap[K any, V any] interface {
+	// Put adds the key or replaces its value, returns true when the key is new
+	Put(key K, value V) bool
+	Get(key K) (V, bool)
+	Delete(key K) bool
+	Min() (K, V, bool)
+	Max() (K, V, bool)
+	// Range visits the keys between lo and hi inclusive
+	Range(lo K, hi K, visit func(key K, value V) bool)
+	Ascend(visit func(key K, value V) bool)
+	Len() int
+}
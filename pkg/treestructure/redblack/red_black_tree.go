@@ -0,0 +1,258 @@
+package redblack
+
+import (
+	"github.com/drprado2/go-backend-framework/pkg/treestructure"
+)
+
+const (
+	red   = true
+	black = false
+)
+
+type node[K any, V any] struct {
+	left  *node[K, V]
+	right *node[K, V]
+	key   K
+	value V
+	color bool
+}
+
+// RedBlackTree is a left leaning red-black tree, red links only lean left so every path from the root
+// to a leaf has the same number of black links and the height is at most 2 log n
+type RedBlackTree[K any, V any] struct {
+	root    *node[K, V]
+	length  int
+	compare treestructure.Comparator[K]
+}
+
+var _ treestructure.OrderedMap[int, int] = (*RedBlackTree[int, int])(nil)
+
+func NewRedBlackTree[K any, V any](compare treestructure.Comparator[K]) *RedBlackTree[K, V] {
+	return &RedBlackTree[K, V]{
+		compare: compare,
+	}
+}
+
+func isRed[K any, V any](n *node[K, V]) bool {
+	return n != nil && n.color == red
+}
+
+func rotateLeft[K any, V any](h *node[K, V]) *node[K, V] {
+	x := h.right
+	h.right = x.left
+	x.left = h
+	x.color = h.color
+	h.color = red
+	return x
+}
+
+func rotateRight[K any, V any](h *node[K, V]) *node[K, V] {
+	x := h.left
+	h.left = x.right
+	x.right = h
+	x.color = h.color
+	h.color = red
+	return x
+}
+
+func flipColors[K any, V any](h *node[K, V]) {
+	h.color = !h.color
+	h.left.color = !h.left.color
+	h.right.color = !h.right.color
+}
+
+// balance restores the left leaning invariants on the way up
+func balance[K any, V any](h *node[K, V]) *node[K, V] {
+	if isRed(h.right) && !isRed(h.left) {
+		h = rotateLeft(h)
+	}
+	if isRed(h.left) && isRed(h.left.left) {
+		h = rotateRight(h)
+	}
+	if isRed(h.left) && isRed(h.right) {
+		flipColors(h)
+	}
+	return h
+}
+
+func (t *RedBlackTree[K, V]) Put(key K, value V) bool {
+	var added bool
+	t.root, added = t.put(t.root, key, value)
+	t.root.color = black
+	if added {
+		t.length++
+	}
+	return added
+}
+
+func (t *RedBlackTree[K, V]) put(h *node[K, V], key K, value V) (*node[K, V], bool) {
+	if h == nil {
+		return &node[K, V]{key: key, value: value, color: red}, true
+	}
+	var added bool
+	if comparison := t.compare(key, h.key); comparison < 0 {
+		h.left, added = t.put(h.left, key, value)
+	} else if comparison > 0 {
+		h.right, added = t.put(h.right, key, value)
+	} else {
+		h.value = value
+	}
+	return balance(h), added
+}
+
+func (t *RedBlackTree[K, V]) find(key K) *node[K, V] {
+	current := t.root
+	for current != nil {
+		if comparison := t.compare(key, current.key); comparison < 0 {
+			current = current.left
+		} else if comparison > 0 {
+			current = current.right
+		} else {
+			return current
+		}
+	}
+	return nil
+}
+
+func (t *RedBlackTree[K, V]) Get(key K) (V, bool) {
+	if found := t.find(key); found != nil {
+		return found.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (t *RedBlackTree[K, V]) Delete(key K) bool {
+	if t.find(key) == nil {
+		return false
+	}
+	if !isRed(t.root.left) && !isRed(t.root.right) {
+		t.root.color = red
+	}
+	t.root = t.delete(t.root, key)
+	if t.root != nil {
+		t.root.color = black
+	}
+	t.length--
+	return true
+}
+
+// moveRedLeft makes the left child or one of its children red before going down to the left
+func moveRedLeft[K any, V any](h *node[K, V]) *node[K, V] {
+	flipColors(h)
+	if isRed(h.right.left) {
+		h.right = rotateRight(h.right)
+		h = rotateLeft(h)
+		flipColors(h)
+	}
+	return h
+}
+
+// moveRedRight makes the right child or one of its children red before going down to the right
+func moveRedRight[K any, V any](h *node[K, V]) *node[K, V] {
+	flipColors(h)
+	if isRed(h.left.left) {
+		h = rotateRight(h)
+		flipColors(h)
+	}
+	return h
+}
+
+func deleteMin[K any, V any](h *node[K, V]) *node[K, V] {
+	if h.left == nil {
+		return nil
+	}
+	if !isRed(h.left) && !isRed(h.left.left) {
+		h = moveRedLeft(h)
+	}
+	h.left = deleteMin(h.left)
+	return balance(h)
+}
+
+// delete expects the key to exist in the subtree
+func (t *RedBlackTree[K, V]) delete(h *node[K, V], key K) *node[K, V] {
+	if t.compare(key, h.key) < 0 {
+		if !isRed(h.left) && !isRed(h.left.left) {
+			h = moveRedLeft(h)
+		}
+		h.left = t.delete(h.left, key)
+		return balance(h)
+	}
+	if isRed(h.left) {
+		h = rotateRight(h)
+	}
+	if t.compare(key, h.key) == 0 && h.right == nil {
+		return nil
+	}
+	if !isRed(h.right) && !isRed(h.right.left) {
+		h = moveRedRight(h)
+	}
+	if t.compare(key, h.key) == 0 {
+		successor := h.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		h.key, h.value = successor.key, successor.value
+		h.right = deleteMin(h.right)
+	} else {
+		h.right = t.delete(h.right, key)
+	}
+	return balance(h)
+}
+
+func (t *RedBlackTree[K, V]) Min() (K, V, bool) {
+	if t.root == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	current := t.root
+	for current.left != nil {
+		current = current.left
+	}
+	return current.key, current.value, true
+}
+
+func (t *RedBlackTree[K, V]) Max() (K, V, bool) {
+	if t.root == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	current := t.root
+	for current.right != nil {
+		current = current.right
+	}
+	return current.key, current.value, true
+}
+
+func (t *RedBlackTree[K, V]) Range(lo K, hi K, visit func(key K, value V) bool) {
+	var walk func(current *node[K, V]) bool
+	walk = func(current *node[K, V]) bool {
+		if current == nil {
+			return true
+		}
+		aboveLo := t.compare(current.key, lo) >= 0
+		belowHi := t.compare(current.key, hi) <= 0
+		if aboveLo && !walk(current.left) {
+			return false
+		}
+		if aboveLo && belowHi && !visit(current.key, current.value) {
+			return false
+		}
+		return !belowHi || walk(current.right)
+	}
+	walk(t.root)
+}
+
+func (t *RedBlackTree[K, V]) Ascend(visit func(key K, value V) bool) {
+	var walk func(current *node[K, V]) bool
+	walk = func(current *node[K, V]) bool {
+		return current == nil || (walk(current.left) && visit(current.key, current.value) && walk(current.right))
+	}
+	walk(t.root)
+}
+
+func (t *RedBlackTree[K, V]) Len() int {
+	return t.length
+}
@@ -0,0 +1,142 @@
+package redblack
+
+import (
+	"github.com/drprado2/go-backend-framework/pkg/treestructure"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// checkInvariants verifies the order, that red links lean left without two in a row and that every path
+// has the same number of black links
+func checkInvariants(t *testing.T, tree *RedBlackTree[int, int]) {
+	t.Helper()
+	if isRed(tree.root) {
+		t.Fatalf("Root must be black")
+	}
+	var check func(n *node[int, int], min *int, max *int) int
+	check = func(n *node[int, int], min *int, max *int) int {
+		if n == nil {
+			return 0
+		}
+		if (min != nil && n.key <= *min) || (max != nil && n.key >= *max) {
+			t.Fatalf("Node %v breaks the search order", n.key)
+		}
+		if isRed(n.right) {
+			t.Fatalf("Node %v has a red right link", n.key)
+		}
+		if isRed(n) && isRed(n.left) {
+			t.Fatalf("Node %v has two red links in a row", n.key)
+		}
+		left := check(n.left, min, &n.key)
+		right := check(n.right, &n.key, max)
+		if left != right {
+			t.Fatalf("Node %v black height left %v right %v", n.key, left, right)
+		}
+		if isRed(n) {
+			return left
+		}
+		return left + 1
+	}
+	check(tree.root, nil, nil)
+}
+
+func buildTestTree() *RedBlackTree[int, int] {
+	tree := NewRedBlackTree[int, int](treestructure.CompareOrdered[int])
+	for id := 1; id <= 7; id++ {
+		tree.Put(id*10, id)
+	}
+	return tree
+}
+
+func TestRedBlackTree_PutGet(t *testing.T) {
+	tree := buildTestTree()
+	checkInvariants(t, tree)
+	if value, ok := tree.Get(50); !ok || value != 5 {
+		t.Errorf("Key 50 must have value 5 got %v", value)
+	}
+	if _, ok := tree.Get(55); ok {
+		t.Errorf("Key 55 must not exist")
+	}
+	if tree.Put(50, 50) || tree.Len() != 7 {
+		t.Errorf("Put of an existing key must replace the value, len must be 7 got %v", tree.Len())
+	}
+	if value, _ := tree.Get(50); value != 50 {
+		t.Errorf("Key 50 must have the value 50 got %v", value)
+	}
+}
+
+func TestRedBlackTree_MinMaxRange(t *testing.T) {
+	tree := buildTestTree()
+	if key, _, _ := tree.Min(); key != 10 {
+		t.Errorf("Min must be 10 got %v", key)
+	}
+	if key, _, _ := tree.Max(); key != 70 {
+		t.Errorf("Max must be 70 got %v", key)
+	}
+	keys := make([]int, 0)
+	tree.Range(25, 60, func(key int, value int) bool {
+		keys = append(keys, key)
+		return key < 50
+	})
+	if len(keys) != 3 || keys[0] != 30 || keys[2] != 50 {
+		t.Errorf("Range must visit 30 to 50 and stop got %v", keys)
+	}
+
+	empty := NewRedBlackTree[int, int](treestructure.CompareOrdered[int])
+	if _, _, ok := empty.Min(); ok || empty.Delete(1) {
+		t.Errorf("Empty tree must not have keys")
+	}
+}
+
+func TestRedBlackTree_RandomOperationsKeepInvariants(t *testing.T) {
+	random := rand.New(rand.NewSource(42))
+	tree := NewRedBlackTree[int, int](treestructure.CompareOrdered[int])
+	expected := make(map[int]int)
+
+	for i := 0; i < 5000; i++ {
+		key := random.Intn(1000)
+		if random.Intn(3) == 0 {
+			_, exists := expected[key]
+			if tree.Delete(key) != exists {
+				t.Fatalf("Delete of %v must return %v", key, exists)
+			}
+			delete(expected, key)
+		} else {
+			_, exists := expected[key]
+			if tree.Put(key, i) == exists {
+				t.Fatalf("Put of %v must return %v", key, !exists)
+			}
+			expected[key] = i
+		}
+		if i%100 == 0 {
+			checkInvariants(t, tree)
+		}
+	}
+	checkInvariants(t, tree)
+
+	keys := make([]int, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	if tree.Len() != len(keys) {
+		t.Fatalf("Len must be %v got %v", len(keys), tree.Len())
+	}
+	position := 0
+	tree.Ascend(func(key int, value int) bool {
+		if key != keys[position] || value != expected[key] {
+			t.Fatalf("Key at %v must be %v with value %v got %v with %v", position, keys[position], expected[key], key, value)
+		}
+		position++
+		return true
+	})
+
+	for _, key := range keys {
+		tree.Delete(key)
+	}
+	checkInvariants(t, tree)
+	if tree.Len() != 0 || tree.root != nil {
+		t.Errorf("Tree must be empty got %v keys", tree.Len())
+	}
+}